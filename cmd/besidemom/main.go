@@ -0,0 +1,149 @@
+package main
+
+import (
+	"Beside-Mom-BE/configs"
+	"Beside-Mom-BE/modules/entities"
+	"Beside-Mom-BE/modules/repositories"
+	"Beside-Mom-BE/modules/usecases"
+	"Beside-Mom-BE/pkg/database"
+	"Beside-Mom-BE/pkg/utils"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+)
+
+const usage = `Usage:
+  besidemom admin create  -email <email> -pid <pid> -firstname <name> -lastname <name> [-password <password>]
+  besidemom admin list
+  besidemom admin disable -email <email>
+  besidemom admin reset   -email <email> [-password <password>]
+`
+
+func main() {
+	if len(os.Args) < 3 || os.Args[1] != "admin" {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	config := configs.LoadConfigs()
+	database.InitDB(config.PostgreSQL)
+	db := database.GetDB()
+	usecase := usecases.NewAdminUseCase(
+		repositories.NewGormUserRepository(db),
+		repositories.NewGormSessionRepository(db),
+		repositories.NewGormInviteRepository(db),
+		repositories.NewGormRoleRepository(db),
+		config.JWT,
+		config.Mail,
+	)
+
+	if err := runAdmin(usecase, os.Args[2], os.Args[3:]); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+func runAdmin(usecase usecases.AdminUseCase, action string, args []string) error {
+	fs := flag.NewFlagSet("admin "+action, flag.ExitOnError)
+	email := fs.String("email", "", "admin email")
+	password := fs.String("password", "", "admin password (generated when empty)")
+	pid := fs.String("pid", "", "admin personal ID")
+	firstname := fs.String("firstname", "", "admin firstname")
+	lastname := fs.String("lastname", "", "admin lastname")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	switch action {
+	case "create":
+		if *email == "" || *pid == "" || *firstname == "" || *lastname == "" {
+			return fmt.Errorf("email, pid, firstname and lastname are required")
+		}
+
+		generated, err := passwordOrGenerate(password)
+		if err != nil {
+			return err
+		}
+
+		admin, err := usecase.CreateAdmin(&entities.User{
+			Email:     *email,
+			Password:  *password,
+			PID:       *pid,
+			Firstname: *firstname,
+			Lastname:  *lastname,
+		})
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Admin %s created (%s)\n", admin.Email, admin.ID)
+		if generated {
+			fmt.Printf("Generated password: %s\n", *password)
+		}
+	case "list":
+		admins, err := usecase.GetAllStaff()
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tEMAIL\tNAME\tROLE\tSTATUS")
+		for _, admin := range admins {
+			status := admin.Status
+			if admin.DisabledAt != nil {
+				status = "disabled"
+			}
+
+			fmt.Fprintf(w, "%s\t%s\t%s %s\t%s\t%s\n", admin.ID, admin.Email, admin.Firstname, admin.Lastname, admin.Role.RoleName, status)
+		}
+
+		return w.Flush()
+	case "disable":
+		if *email == "" {
+			return fmt.Errorf("email is required")
+		}
+
+		if err := usecase.DisableAdmin(*email); err != nil {
+			return err
+		}
+
+		fmt.Printf("Admin %s disabled\n", *email)
+	case "reset":
+		if *email == "" {
+			return fmt.Errorf("email is required")
+		}
+
+		generated, err := passwordOrGenerate(password)
+		if err != nil {
+			return err
+		}
+
+		if err := usecase.ResetAdminPassword(*email, *password); err != nil {
+			return err
+		}
+
+		fmt.Printf("Admin %s password reset\n", *email)
+		if generated {
+			fmt.Printf("Generated password: %s\n", *password)
+		}
+	default:
+		return fmt.Errorf("unknown admin command %q\n%s", action, usage)
+	}
+
+	return nil
+}
+
+func passwordOrGenerate(password *string) (bool, error) {
+	if *password != "" {
+		return false, nil
+	}
+
+	generated, err := utils.GeneratePassword(12)
+	if err != nil {
+		return false, err
+	}
+
+	*password = generated
+	return true, nil
+}
@@ -3,6 +3,7 @@ package configs
 import (
 	"log"
 	"os"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -14,6 +15,8 @@ type Configs struct {
 	Supabase   Supabase
 	Mail       Mail
 	Chat       Chat
+	Report     Report
+	Growth     Growth
 }
 
 type Fiber struct {
@@ -31,14 +34,17 @@ type PostgreSQL struct {
 }
 
 type JWT struct {
-	Secret string
+	Secret        string
+	AccessExpire  time.Duration
+	RefreshExpire time.Duration
 }
 
 type Mail struct {
-	Host   string
-	Port   string
-	Sender string
-	Key    string
+	Host      string
+	Port      string
+	Sender    string
+	Key       string
+	InviteURL string
 }
 
 type Supabase struct {
@@ -51,6 +57,15 @@ type Chat struct {
 	URL string
 }
 
+type Report struct {
+	FontPath     string
+	BoldFontPath string
+}
+
+type Growth struct {
+	FentonPath string
+}
+
 func LoadConfigs() *Configs {
 	err := godotenv.Load()
 	if err != nil {
@@ -71,7 +86,9 @@ func LoadConfigs() *Configs {
 			Port: os.Getenv("APP_PORT"),
 		},
 		JWT: JWT{
-			Secret: os.Getenv("JWT_SECRET"),
+			Secret:        os.Getenv("JWT_SECRET"),
+			AccessExpire:  getDuration("JWT_ACCESS_EXPIRE", 15*time.Minute),
+			RefreshExpire: getDuration("JWT_REFRESH_EXPIRE", 30*24*time.Hour),
 		},
 		Supabase: Supabase{
 			URL:    os.Getenv("SUPABASE_URL"),
@@ -79,13 +96,45 @@ func LoadConfigs() *Configs {
 			Bucket: os.Getenv("BUCKET_NAME"),
 		},
 		Mail: Mail{
-			Host:   os.Getenv("EMAIL_HOST"),
-			Port:   os.Getenv("EMAIL_PORT"),
-			Sender: os.Getenv("EMAIL_USER"),
-			Key:    os.Getenv("EMAIL_PASS"),
+			Host:      os.Getenv("EMAIL_HOST"),
+			Port:      os.Getenv("EMAIL_PORT"),
+			Sender:    os.Getenv("EMAIL_USER"),
+			Key:       os.Getenv("EMAIL_PASS"),
+			InviteURL: os.Getenv("INVITE_URL"),
 		},
 		Chat: Chat{
 			URL: os.Getenv("CHAT_API_URL"),
 		},
+		Report: Report{
+			FontPath:     os.Getenv("REPORT_FONT_PATH"),
+			BoldFontPath: os.Getenv("REPORT_BOLD_FONT_PATH"),
+		},
+		Growth: Growth{
+			FentonPath: getString("GROWTH_FENTON_PATH", "./assets/fenton"),
+		},
+	}
+}
+
+func getString(key string, fallback string) string {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	return value
+}
+
+func getDuration(key string, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
 	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("Invalid duration for %s, using default %s", key, fallback)
+		return fallback
+	}
+
+	return duration
 }
@@ -2,9 +2,17 @@ package main
 
 import (
 	"Beside-Mom-BE/configs"
+	"Beside-Mom-BE/modules/repositories"
 	"Beside-Mom-BE/modules/server"
+	"Beside-Mom-BE/modules/usecases"
 	"Beside-Mom-BE/pkg/database"
+	"Beside-Mom-BE/pkg/growth"
+	"Beside-Mom-BE/pkg/report"
+	"context"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -13,6 +21,12 @@ import (
 func main() {
 	config := configs.LoadConfigs()
 	database.InitDB(config.PostgreSQL)
+	if err := growth.LoadFenton(config.Growth.FentonPath); err != nil {
+		log.Fatalf("Fenton tables unavailable: %v", err)
+	}
+	if err := report.CheckFonts(report.Fonts{Regular: config.Report.FontPath, Bold: config.Report.BoldFontPath}); err != nil {
+		log.Fatalf("Report fonts unavailable: %v", err)
+	}
 	app := fiber.New(fiber.Config{
 		BodyLimit:         2 * 1024 * 1024 * 1024,
 		ReadTimeout:       10 * time.Minute,
@@ -21,8 +35,23 @@ func main() {
 		StreamRequestBody: true,
 	})
 
-	server.SetupRoutes(app, config.JWT, config.Supabase, config.Mail, config.Chat)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	historySync := usecases.NewHistorySyncUseCase(repositories.NewGormHistoryRepository(database.GetDB()))
+	server.SetupRoutes(app, historySync, config.JWT, config.Supabase, config.Mail, config.Chat, config.Report)
+	historySync.Start(ctx)
+
+	go func() {
+		<-ctx.Done()
+		if err := app.Shutdown(); err != nil {
+			log.Printf("Failed to shut down server: %v", err)
+		}
+	}()
+
 	serverAddress := config.App.Host + ":" + config.App.Port
 	log.Printf("Server is running on %s", serverAddress)
-	log.Fatal(app.Listen(serverAddress))
+	if err := app.Listen(serverAddress); err != nil {
+		log.Fatal(err)
+	}
 }
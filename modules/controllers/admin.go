@@ -0,0 +1,110 @@
+package controllers
+
+import (
+	"Beside-Mom-BE/modules/entities"
+	"Beside-Mom-BE/modules/usecases"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AdminController struct {
+	usecase usecases.AdminUseCase
+}
+
+func NewAdminController(usecase usecases.AdminUseCase) *AdminController {
+	return &AdminController{usecase: usecase}
+}
+
+func (c *AdminController) InviteStaffHandler(ctx *fiber.Ctx) error {
+	var req struct {
+		Role      string `json:"role"`
+		Email     string `json:"email"`
+		PID       string `json:"pid"`
+		Firstname string `json:"firstname"`
+		Lastname  string `json:"lastname"`
+	}
+
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	if req.Email == "" || req.Firstname == "" || req.Lastname == "" || req.PID == "" {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     "Email, PID, Firstname and Lastname is required",
+			"result":      nil,
+		})
+	}
+
+	user := &entities.User{
+		Email:     req.Email,
+		PID:       req.PID,
+		Firstname: req.Firstname,
+		Lastname:  req.Lastname,
+	}
+
+	if req.Role == "" {
+		req.Role = "Admin"
+	}
+
+	data, err := c.usecase.InviteStaff(user, req.Role)
+	if err != nil {
+		return ctx.Status(fiber.ErrInternalServerError.Code).JSON(fiber.Map{
+			"status":      fiber.ErrInternalServerError.Message,
+			"status_code": fiber.ErrInternalServerError.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusCreated,
+		"message":     "Staff invited successfully",
+		"result":      data,
+	})
+}
+
+func (c *AdminController) GetAllStaffHandler(ctx *fiber.Ctx) error {
+	data, err := c.usecase.GetAllStaff()
+	if err != nil {
+		return ctx.Status(fiber.ErrInternalServerError.Code).JSON(fiber.Map{
+			"status":      fiber.ErrInternalServerError.Message,
+			"status_code": fiber.ErrInternalServerError.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Staff retrieved successfully",
+		"result":      data,
+	})
+}
+
+func (c *AdminController) GetAllRolesHandler(ctx *fiber.Ctx) error {
+	data, err := c.usecase.GetAllRoles()
+	if err != nil {
+		return ctx.Status(fiber.ErrInternalServerError.Code).JSON(fiber.Map{
+			"status":      fiber.ErrInternalServerError.Message,
+			"status_code": fiber.ErrInternalServerError.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Roles retrieved successfully",
+		"result":      data,
+	})
+}
@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	"Beside-Mom-BE/modules/entities"
+	"Beside-Mom-BE/modules/usecases"
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AnalyticsController struct {
+	usecase usecases.AnalyticsUseCase
+}
+
+func NewAnalyticsController(usecase usecases.AnalyticsUseCase) *AnalyticsController {
+	return &AnalyticsController{usecase: usecase}
+}
+
+func (c *AnalyticsController) GetScreeningAnalyticsHandler(ctx *fiber.Ctx) error {
+	filter, err := analyticsFilter(ctx)
+	if err != nil {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	data, err := c.usecase.GetScreeningAnalytics(filter, ctx.QueryInt("limit", 10))
+	if err != nil {
+		return ctx.Status(fiber.ErrInternalServerError.Code).JSON(fiber.Map{
+			"status":      fiber.ErrInternalServerError.Message,
+			"status_code": fiber.ErrInternalServerError.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Screening analytics retrieved successfully",
+		"result":      data,
+	})
+}
+
+func (c *AnalyticsController) ExportScreeningAnalyticsHandler(ctx *fiber.Ctx) error {
+	filter, err := analyticsFilter(ctx)
+	if err != nil {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	section := ctx.Query("section", "rates")
+	data, err := c.usecase.ExportScreeningCSV(filter, section, ctx.QueryInt("limit", 10))
+	if err != nil {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	ctx.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	ctx.Set(fiber.HeaderContentDisposition, `attachment; filename="screening-`+section+`.csv"`)
+	return ctx.Status(fiber.StatusOK).Send(data)
+}
+
+func analyticsFilter(ctx *fiber.Ctx) (entities.AnalyticsFilter, error) {
+	var filter entities.AnalyticsFilter
+	if from := ctx.Query("from"); from != "" {
+		date, err := time.Parse("2006-01-02", from)
+		if err != nil {
+			return filter, errors.New("Invalid from date format. Use YYYY-MM-DD")
+		}
+		filter.From = &date
+	}
+
+	if to := ctx.Query("to"); to != "" {
+		date, err := time.Parse("2006-01-02", to)
+		if err != nil {
+			return filter, errors.New("Invalid to date format. Use YYYY-MM-DD")
+		}
+		date = date.AddDate(0, 0, 1)
+		filter.To = &date
+	}
+
+	return filter, nil
+}
@@ -3,6 +3,7 @@ package controllers
 import (
 	"Beside-Mom-BE/modules/entities"
 	"Beside-Mom-BE/modules/usecases"
+	"Beside-Mom-BE/pkg/middlewares"
 	"strconv"
 	"time"
 
@@ -75,9 +76,10 @@ func (c *AppController) CreateAppointmentHandler(ctx *fiber.Ctx) error {
 		Doctor:      doctor,
 		Requirement: requirement,
 		Status:      1,
+		KidID:       appointmentKidID(ctx),
 	}
 
-	createdApp, err := c.usecase.CreateAppointment(&appointment)
+	createdApp, err := c.usecase.CreateAppointment(&appointment, ctx)
 	if err != nil {
 		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"status":      "Error",
@@ -136,22 +138,12 @@ func (c *AppController) GetAppHandler(ctx *fiber.Ctx) error {
 		})
 	}
 
-	role, ok := ctx.Locals("role").(string)
-	if !ok || (role != "User" && role != "Admin") {
-		return ctx.Status(fiber.StatusForbidden).JSON(fiber.Map{
-			"status":      "Error",
-			"status_code": fiber.StatusForbidden,
-			"message":     "Forbidden: Invalid role",
-			"result":      nil,
-		})
-	}
-
 	var data interface{}
 	var err error
-	if role == "Admin" {
+	if middlewares.HasPermission(ctx, "appointment:read") {
 		data, err = c.usecase.GetAllApp()
 	} else {
-		data, err = c.usecase.GetAppByUserID(userID)
+		data, err = c.usecase.GetAppByUserIDWithShared(userID)
 	}
 
 	if err != nil {
@@ -296,9 +288,10 @@ func (c *AppController) UpdateAppByIDHandler(ctx *fiber.Ctx) error {
 		Doctor:      doctor,
 		Requirement: requirement,
 		Status:      parsedStatus,
+		KidID:       appointmentKidID(ctx),
 	}
 
-	updatedApp, err := c.usecase.UpdateAppByID(id, &appointment)
+	updatedApp, err := c.usecase.UpdateAppByID(id, &appointment, ctx)
 	if err != nil {
 		return ctx.Status(fiber.ErrNotFound.Code).JSON(fiber.Map{
 			"status":      fiber.ErrNotFound.Message,
@@ -328,7 +321,7 @@ func (c *AppController) DeleteAppByIDHandler(ctx *fiber.Ctx) error {
 		})
 	}
 
-	if err := c.usecase.DeleteAppByID(id); err != nil {
+	if err := c.usecase.DeleteAppByID(id, ctx); err != nil {
 		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"status":      "Error",
 			"status_code": fiber.StatusInternalServerError,
@@ -344,3 +337,12 @@ func (c *AppController) DeleteAppByIDHandler(ctx *fiber.Ctx) error {
 		"result":      nil,
 	})
 }
+
+func appointmentKidID(ctx *fiber.Ctx) *string {
+	kidID := ctx.FormValue("kid_id")
+	if kidID == "" {
+		return nil
+	}
+
+	return &kidID
+}
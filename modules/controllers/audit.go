@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	"Beside-Mom-BE/modules/entities"
+	"Beside-Mom-BE/modules/usecases"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AuditController struct {
+	usecase usecases.AuditUseCase
+}
+
+func NewAuditController(usecase usecases.AuditUseCase) *AuditController {
+	return &AuditController{usecase: usecase}
+}
+
+func (c *AuditController) GetAuditLogsHandler(ctx *fiber.Ctx) error {
+	filter := entities.AuditFilter{
+		ActorID:  ctx.Query("actor_id"),
+		Action:   ctx.Query("action"),
+		Entity:   ctx.Query("entity"),
+		EntityID: ctx.Query("entity_id"),
+	}
+
+	if from := ctx.Query("from"); from != "" {
+		date, err := time.Parse("2006-01-02", from)
+		if err != nil {
+			return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+				"status":      fiber.ErrBadRequest.Message,
+				"status_code": fiber.ErrBadRequest.Code,
+				"message":     "Invalid from date format. Use YYYY-MM-DD",
+				"result":      nil,
+			})
+		}
+		filter.From = &date
+	}
+
+	if to := ctx.Query("to"); to != "" {
+		date, err := time.Parse("2006-01-02", to)
+		if err != nil {
+			return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+				"status":      fiber.ErrBadRequest.Message,
+				"status_code": fiber.ErrBadRequest.Code,
+				"message":     "Invalid to date format. Use YYYY-MM-DD",
+				"result":      nil,
+			})
+		}
+		date = date.AddDate(0, 0, 1)
+		filter.To = &date
+	}
+
+	data, err := c.usecase.GetAuditLogs(filter, ctx.QueryInt("page", 1), ctx.QueryInt("limit", 20))
+	if err != nil {
+		return ctx.Status(fiber.ErrInternalServerError.Code).JSON(fiber.Map{
+			"status":      fiber.ErrInternalServerError.Message,
+			"status_code": fiber.ErrInternalServerError.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Audit logs retrieved successfully",
+		"result":      data,
+	})
+}
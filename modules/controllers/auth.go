@@ -1,7 +1,6 @@
 package controllers
 
 import (
-	"Beside-Mom-BE/modules/entities"
 	"Beside-Mom-BE/modules/usecases"
 
 	"github.com/gofiber/fiber/v2"
@@ -15,42 +14,40 @@ func NewAuthController(usecase usecases.AuthUseCase) *AuthController {
 	return &AuthController{usecase: usecase}
 }
 
-func (c *AuthController) RegisterHandler(ctx *fiber.Ctx) error {
+func (c *AuthController) LoginHandler(ctx *fiber.Ctx) error {
 	var req struct {
-		Email     string `json:"email"`
-		Password  string `json:"password"`
-		PID       string `json:"pid"`
-		Firstname string `json:"firstname"`
-		Lastname  string `json:"lastname"`
+		Email    string `json:"email"`
+		Password string `json:"password"`
 	}
 
 	if err := ctx.BodyParser(&req); err != nil {
-		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
-			"status":      fiber.ErrBadRequest.Message,
-			"status_code": fiber.ErrBadRequest.Code,
+		return ctx.Status(fiber.ErrInternalServerError.Code).JSON(fiber.Map{
+			"status":      fiber.ErrInternalServerError.Message,
+			"status_code": fiber.ErrInternalServerError.Code,
 			"message":     err.Error(),
 			"result":      nil,
 		})
 	}
 
-	if req.Email == "" || req.Password == "" || req.Firstname == "" || req.Lastname == "" || req.PID == "" {
+	if req.Email == "" {
 		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
 			"status":      "Error",
 			"status_code": fiber.ErrBadRequest.Code,
-			"message":     "Email, Password, Firstname and Lastname is required",
+			"message":     "Email is missing",
 			"result":      nil,
 		})
 	}
 
-	user := &entities.User{
-		Email:     req.Email,
-		Password:  req.Password,
-		PID:       req.PID,
-		Firstname: req.Firstname,
-		Lastname:  req.Lastname,
+	if req.Password == "" {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     "Password is missing",
+			"result":      nil,
+		})
 	}
 
-	data, err := c.usecase.RegisterAdmin(user)
+	token, refreshToken, user, err := c.usecase.Login(req.Email, req.Password, ctx.Get("User-Agent"), ctx.IP())
 	if err != nil {
 		return ctx.Status(fiber.ErrInternalServerError.Code).JSON(fiber.Map{
 			"status":      fiber.ErrInternalServerError.Message,
@@ -60,49 +57,76 @@ func (c *AuthController) RegisterHandler(ctx *fiber.Ctx) error {
 		})
 	}
 
-	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status":      "Success",
 		"status_code": fiber.StatusOK,
-		"message":     "user created successfully",
-		"result":      data,
+		"message":     "Login successful",
+		"result": fiber.Map{
+			"token":         token,
+			"refresh_token": refreshToken,
+			"u_id":          user.ID,
+			"name":          user.Firstname + " " + user.Lastname,
+			"role":          user.Role.RoleName,
+		},
 	})
 }
 
-func (c *AuthController) LoginHandler(ctx *fiber.Ctx) error {
+func (c *AuthController) RefreshTokenHandler(ctx *fiber.Ctx) error {
 	var req struct {
-		Email    string `json:"email"`
-		Password string `json:"password"`
+		RefreshToken string `json:"refresh_token"`
 	}
 
 	if err := ctx.BodyParser(&req); err != nil {
-		return ctx.Status(fiber.ErrInternalServerError.Code).JSON(fiber.Map{
-			"status":      fiber.ErrInternalServerError.Message,
-			"status_code": fiber.ErrInternalServerError.Code,
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
 			"message":     err.Error(),
 			"result":      nil,
 		})
 	}
 
-	if req.Email == "" {
+	if req.RefreshToken == "" {
 		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
 			"status":      "Error",
 			"status_code": fiber.ErrBadRequest.Code,
-			"message":     "Email is missing",
+			"message":     "Refresh token is missing",
 			"result":      nil,
 		})
 	}
 
-	if req.Password == "" {
-		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+	token, refreshToken, err := c.usecase.RefreshToken(req.RefreshToken, ctx.Get("User-Agent"), ctx.IP())
+	if err != nil {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":      "Unauthorized",
+			"status_code": fiber.StatusUnauthorized,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Token refreshed successfully",
+		"result": fiber.Map{
+			"token":         token,
+			"refresh_token": refreshToken,
+		},
+	})
+}
+
+func (c *AuthController) LogoutHandler(ctx *fiber.Ctx) error {
+	sessionID, ok := ctx.Locals("session_id").(string)
+	if !ok || sessionID == "" {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
 			"status":      "Error",
-			"status_code": fiber.ErrBadRequest.Code,
-			"message":     "Password is missing",
+			"status_code": fiber.StatusUnauthorized,
+			"message":     "Unauthorized: Missing session ID",
 			"result":      nil,
 		})
 	}
 
-	token, user, err := c.usecase.Login(req.Email, req.Password)
-	if err != nil {
+	if err := c.usecase.Logout(sessionID); err != nil {
 		return ctx.Status(fiber.ErrInternalServerError.Code).JSON(fiber.Map{
 			"status":      fiber.ErrInternalServerError.Message,
 			"status_code": fiber.ErrInternalServerError.Code,
@@ -114,12 +138,29 @@ func (c *AuthController) LoginHandler(ctx *fiber.Ctx) error {
 	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status":      "Success",
 		"status_code": fiber.StatusOK,
-		"message":     "Login successful",
+		"message":     "Logout successful",
+		"result":      nil,
+	})
+}
+
+func (c *AuthController) RevokeAllSessionsHandler(ctx *fiber.Ctx) error {
+	momID := ctx.Params("id")
+	revoked, err := c.usecase.RevokeAllSessions(momID)
+	if err != nil {
+		return ctx.Status(fiber.ErrNotFound.Code).JSON(fiber.Map{
+			"status":      fiber.ErrNotFound.Message,
+			"status_code": fiber.ErrNotFound.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Sessions revoked successfully",
 		"result": fiber.Map{
-			"token": token,
-			"u_id":  user.ID,
-			"name":  user.Firstname + " " + user.Lastname,
-			"role":  user.Role.RoleName,
+			"revoked": revoked,
 		},
 	})
 }
@@ -199,7 +240,7 @@ func (c *AuthController) VerifyOTPHandler(ctx *fiber.Ctx) error {
 		})
 	}
 
-	err := c.usecase.VerifyOTP(req.Email, req.OTP)
+	resetToken, err := c.usecase.VerifyOTP(req.Email, req.OTP)
 	if err != nil {
 		return ctx.Status(fiber.ErrInternalServerError.Code).JSON(fiber.Map{
 			"status":      fiber.ErrInternalServerError.Message,
@@ -213,12 +254,16 @@ func (c *AuthController) VerifyOTPHandler(ctx *fiber.Ctx) error {
 		"status":      "Success",
 		"status_code": fiber.StatusOK,
 		"message":     "OTP is correct",
+		"result": fiber.Map{
+			"reset_token": resetToken,
+		},
 	})
 }
 
 func (c *AuthController) ChangedPasswordHandler(ctx *fiber.Ctx) error {
 	type OTPRequest struct {
 		Email       string `json:"email" validate:"required,email"`
+		ResetToken  string `json:"reset_token"`
 		NewPassword string `json:"newPassword"`
 	}
 
@@ -241,6 +286,15 @@ func (c *AuthController) ChangedPasswordHandler(ctx *fiber.Ctx) error {
 		})
 	}
 
+	if req.ResetToken == "" {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     "Reset token is missing",
+			"result":      nil,
+		})
+	}
+
 	if req.NewPassword == "" {
 		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
 			"status":      "Error",
@@ -250,7 +304,7 @@ func (c *AuthController) ChangedPasswordHandler(ctx *fiber.Ctx) error {
 		})
 	}
 
-	err := c.usecase.ChangedPassword(req.Email, req.NewPassword)
+	err := c.usecase.ChangedPassword(req.Email, req.ResetToken, req.NewPassword)
 	if err != nil {
 		return ctx.Status(fiber.ErrInternalServerError.Code).JSON(fiber.Map{
 			"status":      fiber.ErrInternalServerError.Message,
@@ -0,0 +1,185 @@
+package controllers
+
+import (
+	"Beside-Mom-BE/modules/entities"
+	"Beside-Mom-BE/modules/usecases"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type CaregiverController struct {
+	usecase usecases.CaregiverUseCase
+}
+
+func NewCaregiverController(usecase usecases.CaregiverUseCase) *CaregiverController {
+	return &CaregiverController{usecase: usecase}
+}
+
+func (c *CaregiverController) InviteCaregiverHandler(ctx *fiber.Ctx) error {
+	kidID := ctx.Params("id")
+	userID, ok := ctx.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusUnauthorized,
+			"message":     "Unauthorized: Missing user ID",
+			"result":      nil,
+		})
+	}
+
+	var req struct {
+		Email     string `json:"email"`
+		Firstname string `json:"firstname"`
+		Lastname  string `json:"lastname"`
+		Relation  string `json:"relation"`
+		Access    string `json:"access"`
+	}
+
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	if req.Email == "" {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     "Email is missing",
+			"result":      nil,
+		})
+	}
+
+	if req.Access == "" {
+		req.Access = "view"
+	}
+
+	user := &entities.User{
+		Email:     req.Email,
+		Firstname: req.Firstname,
+		Lastname:  req.Lastname,
+	}
+
+	data, err := c.usecase.InviteCaregiver(kidID, userID, user, req.Relation, req.Access)
+	if err != nil {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusCreated,
+		"message":     "Caregiver added successfully",
+		"result":      data,
+	})
+}
+
+func (c *CaregiverController) GetCaregiversHandler(ctx *fiber.Ctx) error {
+	kidID := ctx.Params("id")
+	data, err := c.usecase.GetCaregivers(kidID)
+	if err != nil {
+		return ctx.Status(fiber.ErrNotFound.Code).JSON(fiber.Map{
+			"status":      fiber.ErrNotFound.Message,
+			"status_code": fiber.ErrNotFound.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Caregivers retrieved successfully",
+		"result":      data,
+	})
+}
+
+func (c *CaregiverController) UpdateCaregiverHandler(ctx *fiber.Ctx) error {
+	kidID := ctx.Params("id")
+	caregiverID := ctx.Params("caregiverID")
+	var req struct {
+		Access string `json:"access"`
+	}
+
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	data, err := c.usecase.UpdateCaregiverAccess(kidID, caregiverID, req.Access)
+	if err != nil {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Caregiver updated successfully",
+		"result":      data,
+	})
+}
+
+func (c *CaregiverController) DeleteCaregiverHandler(ctx *fiber.Ctx) error {
+	kidID := ctx.Params("id")
+	caregiverID := ctx.Params("caregiverID")
+	if err := c.usecase.RemoveCaregiver(kidID, caregiverID); err != nil {
+		return ctx.Status(fiber.ErrNotFound.Code).JSON(fiber.Map{
+			"status":      fiber.ErrNotFound.Message,
+			"status_code": fiber.ErrNotFound.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Caregiver removed successfully",
+		"result":      nil,
+	})
+}
+
+func (c *CaregiverController) GetSharedKidsHandler(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusUnauthorized,
+			"message":     "Unauthorized: Missing user ID",
+			"result":      nil,
+		})
+	}
+
+	data, err := c.usecase.GetSharedKids(userID)
+	if err != nil {
+		return ctx.Status(fiber.ErrInternalServerError.Code).JSON(fiber.Map{
+			"status":      fiber.ErrInternalServerError.Message,
+			"status_code": fiber.ErrInternalServerError.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Shared kids retrieved successfully",
+		"result":      data,
+	})
+}
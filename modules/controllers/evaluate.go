@@ -26,7 +26,7 @@ func (c *EvaluateController) GetAllEvaluateHandler(ctx *fiber.Ctx) error {
 		})
 	}
 
-	data, err := c.usecase.GetAllEvaluate(kidID)
+	data, err := c.usecase.GetAllEvaluate(kidID, requestLanguage(ctx))
 	if err != nil {
 		return ctx.Status(fiber.ErrNotFound.Code).JSON(fiber.Map{
 			"status":      fiber.ErrNotFound.Message,
@@ -43,3 +43,22 @@ func (c *EvaluateController) GetAllEvaluateHandler(ctx *fiber.Ctx) error {
 		"result":      data,
 	})
 }
+
+func (c *EvaluateController) GetOverdueKidsHandler(ctx *fiber.Ctx) error {
+	data, err := c.usecase.GetOverdueKids()
+	if err != nil {
+		return ctx.Status(fiber.ErrInternalServerError.Code).JSON(fiber.Map{
+			"status":      fiber.ErrInternalServerError.Message,
+			"status_code": fiber.ErrInternalServerError.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Overdue evaluations retrieved successfully",
+		"result":      data,
+	})
+}
@@ -3,6 +3,7 @@ package controllers
 import (
 	"Beside-Mom-BE/modules/entities"
 	"Beside-Mom-BE/modules/usecases"
+	"errors"
 	"strconv"
 	"time"
 
@@ -71,14 +72,25 @@ func (c *GrowthController) CreateGrowthHandler(ctx *fiber.Ctx) error {
 		})
 	}
 
+	headCircumference, err := parseHeadCircumference(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusBadRequest,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
 	growth := &entities.Growth{
-		ID:     uuid.New().String(),
-		Length: length,
-		Weight: weight,
-		KidID:  kidID,
+		ID:                uuid.New().String(),
+		Length:            length,
+		Weight:            weight,
+		HeadCircumference: headCircumference,
+		KidID:             kidID,
 	}
 
-	growth, err = c.usecase.CreateGrowth(kidID, growth, date)
+	growth, err = c.usecase.CreateGrowth(kidID, growth, date, ctx)
 	if err != nil {
 		return ctx.Status(fiber.ErrInternalServerError.Code).JSON(fiber.Map{
 			"status":      fiber.ErrInternalServerError.Message,
@@ -209,13 +221,24 @@ func (c *GrowthController) UpdateGrowthByID(ctx *fiber.Ctx) error {
 		})
 	}
 
+	headCircumference, err := parseHeadCircumference(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusBadRequest,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
 	growth := &entities.Growth{
-		Length:    length,
-		Weight:    weight,
-		CreatedAt: date,
+		Length:            length,
+		Weight:            weight,
+		HeadCircumference: headCircumference,
+		CreatedAt:         date,
 	}
 
-	growth, err = c.usecase.UpdateGrowthByID(id, growth)
+	growth, err = c.usecase.UpdateGrowthByID(id, growth, ctx)
 	if err != nil {
 		return ctx.Status(fiber.ErrInternalServerError.Code).JSON(fiber.Map{
 			"status":      fiber.ErrInternalServerError.Message,
@@ -232,3 +255,143 @@ func (c *GrowthController) UpdateGrowthByID(ctx *fiber.Ctx) error {
 		"result":      growth,
 	})
 }
+
+func (c *GrowthController) GetAlertsHandler(ctx *fiber.Ctx) error {
+	alerts, err := c.usecase.GetAlerts(ctx.Query("severity"))
+	if err != nil {
+		return ctx.Status(fiber.ErrInternalServerError.Code).JSON(fiber.Map{
+			"status":      fiber.ErrInternalServerError.Message,
+			"status_code": fiber.ErrInternalServerError.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Growth alerts retrieved successfully",
+		"result":      alerts,
+	})
+}
+
+func (c *GrowthController) GetChartSVGHandler(ctx *fiber.Ctx) error {
+	return c.getChart(ctx, "svg", "image/svg+xml")
+}
+
+func (c *GrowthController) GetChartPNGHandler(ctx *fiber.Ctx) error {
+	return c.getChart(ctx, "png", "image/png")
+}
+
+func (c *GrowthController) getChart(ctx *fiber.Ctx, format string, contentType string) error {
+	chart, err := c.usecase.GetChart(ctx.Params("id"), ctx.Query("metric", "weight"), format)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusBadRequest,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	ctx.Set(fiber.HeaderContentType, contentType)
+	ctx.Set(fiber.HeaderCacheControl, "no-store")
+	return ctx.Status(fiber.StatusOK).Send(chart)
+}
+
+func (c *GrowthController) ImportGrowthHandler(ctx *fiber.Ctx) error {
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusBadRequest,
+			"message":     "CSV file is required",
+			"result":      nil,
+		})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return ctx.Status(fiber.ErrInternalServerError.Code).JSON(fiber.Map{
+			"status":      fiber.ErrInternalServerError.Message,
+			"status_code": fiber.ErrInternalServerError.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+	defer file.Close()
+
+	results, err := c.usecase.ImportGrowth(file, ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusBadRequest,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	counts := map[string]int{"created": 0, "updated": 0, "error": 0}
+	for _, result := range results {
+		counts[result.Status]++
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Growth import processed",
+		"result": fiber.Map{
+			"created": counts["created"],
+			"updated": counts["updated"],
+			"failed":  counts["error"],
+			"rows":    results,
+		},
+	})
+}
+
+func (c *GrowthController) ExportGrowthHandler(ctx *fiber.Ctx) error {
+	kidID := ctx.Params("id")
+	data, err := c.usecase.ExportGrowthCSV(kidID)
+	if err != nil {
+		return ctx.Status(fiber.ErrInternalServerError.Code).JSON(fiber.Map{
+			"status":      fiber.ErrInternalServerError.Message,
+			"status_code": fiber.ErrInternalServerError.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	ctx.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	ctx.Set(fiber.HeaderContentDisposition, `attachment; filename="growth-`+kidID+`.csv"`)
+	return ctx.Status(fiber.StatusOK).Send(data)
+}
+
+func (c *GrowthController) ExportAllGrowthHandler(ctx *fiber.Ctx) error {
+	data, err := c.usecase.ExportAllGrowthCSV()
+	if err != nil {
+		return ctx.Status(fiber.ErrInternalServerError.Code).JSON(fiber.Map{
+			"status":      fiber.ErrInternalServerError.Message,
+			"status_code": fiber.ErrInternalServerError.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	ctx.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	ctx.Set(fiber.HeaderContentDisposition, `attachment; filename="growth-all.csv"`)
+	return ctx.Status(fiber.StatusOK).Send(data)
+}
+
+func parseHeadCircumference(ctx *fiber.Ctx) (*float64, error) {
+	value := ctx.FormValue("head_circumference")
+	if value == "" {
+		return nil, nil
+	}
+
+	headCircumference, err := strconv.ParseFloat(value, 64)
+	if err != nil || headCircumference <= 0 {
+		return nil, errors.New("Invalid head circumference value")
+	}
+
+	return &headCircumference, nil
+}
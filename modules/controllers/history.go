@@ -3,6 +3,7 @@ package controllers
 import (
 	"Beside-Mom-BE/modules/usecases"
 	"fmt"
+	"mime/multipart"
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
@@ -85,7 +86,27 @@ func (c *HistoryController) CreateHistoryHandler(ctx *fiber.Ctx) error {
 		answers = append(answers, parsed)
 	}
 
-	err = c.usecase.CreateHistoryInPeriodandHistory(id, cate, kidID, answers)
+	rawNotes := form.Value["note"]
+	if len(rawNotes) > len(answers) {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusBadRequest,
+			"message":     "There are more notes than answers",
+			"result":      nil,
+		})
+	}
+
+	notes := make([]string, len(answers))
+	copy(notes, rawNotes)
+
+	media := make([]*multipart.FileHeader, len(answers))
+	for i := range answers {
+		if files := form.File[fmt.Sprintf("media_%d", i)]; len(files) > 0 {
+			media[i] = files[0]
+		}
+	}
+
+	err = c.usecase.CreateHistoryInPeriodandHistory(id, cate, kidID, answers, notes, media, ctx)
 	if err != nil {
 		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"status":      "Error",
@@ -125,7 +146,7 @@ func (c *HistoryController) GetHistoryHandler(ctx *fiber.Ctx) error {
 		})
 	}
 
-	data, err := c.usecase.GetHistoryOfEvaluate(id, kidID)
+	data, err := c.usecase.GetHistoryOfEvaluate(id, kidID, requestLanguage(ctx))
 	if err != nil {
 		return ctx.Status(fiber.ErrNotFound.Code).JSON(fiber.Map{
 			"status":      fiber.ErrNotFound.Message,
@@ -218,7 +239,7 @@ func (c *HistoryController) GetHistoryResultHandler(ctx *fiber.Ctx) error {
 		})
 	}
 
-	data, err := c.usecase.GetHistoryResult(id, kidID)
+	data, err := c.usecase.GetHistoryResult(id, kidID, requestLanguage(ctx))
 	if err != nil {
 		return ctx.Status(fiber.ErrNotFound.Code).JSON(fiber.Map{
 			"status":      fiber.ErrNotFound.Message,
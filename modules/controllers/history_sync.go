@@ -0,0 +1,53 @@
+package controllers
+
+import (
+	"Beside-Mom-BE/modules/usecases"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type HistorySyncController struct {
+	usecase usecases.HistorySyncUseCase
+}
+
+func NewHistorySyncController(usecase usecases.HistorySyncUseCase) *HistorySyncController {
+	return &HistorySyncController{usecase: usecase}
+}
+
+func (c *HistorySyncController) PreviewHistorySyncHandler(ctx *fiber.Ctx) error {
+	report, err := c.usecase.Preview()
+	if err != nil {
+		return ctx.Status(fiber.ErrInternalServerError.Code).JSON(fiber.Map{
+			"status":      fiber.ErrInternalServerError.Message,
+			"status_code": fiber.ErrInternalServerError.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "History sync preview generated successfully",
+		"result":      report,
+	})
+}
+
+func (c *HistorySyncController) GetLastHistorySyncHandler(ctx *fiber.Ctx) error {
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Last history sync retrieved successfully",
+		"result":      c.usecase.LastReport(),
+	})
+}
+
+func (c *HistorySyncController) TriggerHistorySyncHandler(ctx *fiber.Ctx) error {
+	c.usecase.Trigger()
+	return ctx.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusAccepted,
+		"message":     "History sync scheduled",
+		"result":      nil,
+	})
+}
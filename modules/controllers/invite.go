@@ -0,0 +1,135 @@
+package controllers
+
+import (
+	"Beside-Mom-BE/modules/usecases"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type InviteController struct {
+	usecase usecases.InviteUseCase
+}
+
+func NewInviteController(usecase usecases.InviteUseCase) *InviteController {
+	return &InviteController{usecase: usecase}
+}
+
+func (c *InviteController) AcceptInviteHandler(ctx *fiber.Ctx) error {
+	var req struct {
+		Token    string `json:"token"`
+		Password string `json:"password"`
+	}
+
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	if req.Token == "" || req.Password == "" {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     "Token and Password is required",
+			"result":      nil,
+		})
+	}
+
+	user, err := c.usecase.AcceptInvite(req.Token, req.Password)
+	if err != nil {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Invite accepted successfully",
+		"result":      user,
+	})
+}
+
+func (c *InviteController) ResendInviteHandler(ctx *fiber.Ctx) error {
+	momID := ctx.Params("id")
+	data, err := c.usecase.ResendInvite(momID)
+	if err != nil {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Invite resent successfully",
+		"result":      data,
+	})
+}
+
+func (c *InviteController) RevokeInviteHandler(ctx *fiber.Ctx) error {
+	momID := ctx.Params("id")
+	if err := c.usecase.RevokeInvite(momID); err != nil {
+		return ctx.Status(fiber.ErrNotFound.Code).JSON(fiber.Map{
+			"status":      fiber.ErrNotFound.Message,
+			"status_code": fiber.ErrNotFound.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Invite revoked successfully",
+		"result":      nil,
+	})
+}
+
+func (c *InviteController) GetInviteByUserIDHandler(ctx *fiber.Ctx) error {
+	momID := ctx.Params("id")
+	data, err := c.usecase.GetInviteByUserID(momID)
+	if err != nil {
+		return ctx.Status(fiber.ErrNotFound.Code).JSON(fiber.Map{
+			"status":      fiber.ErrNotFound.Message,
+			"status_code": fiber.ErrNotFound.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Invite retrieved successfully",
+		"result":      data,
+	})
+}
+
+func (c *InviteController) GetAllInvitesHandler(ctx *fiber.Ctx) error {
+	data, err := c.usecase.GetAllInvites()
+	if err != nil {
+		return ctx.Status(fiber.ErrInternalServerError.Code).JSON(fiber.Map{
+			"status":      fiber.ErrInternalServerError.Message,
+			"status_code": fiber.ErrInternalServerError.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Invites retrieved successfully",
+		"result":      data,
+	})
+}
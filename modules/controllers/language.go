@@ -0,0 +1,11 @@
+package controllers
+
+import "github.com/gofiber/fiber/v2"
+
+func requestLanguage(ctx *fiber.Ctx) string {
+	if lang := ctx.AcceptsLanguages("th", "en"); lang != "" {
+		return lang
+	}
+
+	return "th"
+}
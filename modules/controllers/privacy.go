@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"Beside-Mom-BE/modules/usecases"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+type PrivacyController struct {
+	usecase usecases.PrivacyUseCase
+}
+
+func NewPrivacyController(usecase usecases.PrivacyUseCase) *PrivacyController {
+	return &PrivacyController{usecase: usecase}
+}
+
+func (c *PrivacyController) ExportUserDataHandler(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusUnauthorized,
+			"message":     "Unauthorized: Missing user ID",
+			"result":      nil,
+		})
+	}
+
+	data, err := c.usecase.ExportUserData(userID)
+	if err != nil {
+		return ctx.Status(fiber.ErrInternalServerError.Code).JSON(fiber.Map{
+			"status":      fiber.ErrInternalServerError.Message,
+			"status_code": fiber.ErrInternalServerError.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	ctx.Set(fiber.HeaderContentType, "application/zip")
+	ctx.Set(fiber.HeaderContentDisposition, `attachment; filename="beside-mom-export.zip"`)
+	return ctx.Status(fiber.StatusOK).Send(data)
+}
+
+func (c *PrivacyController) EraseOwnAccountHandler(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusUnauthorized,
+			"message":     "Unauthorized: Missing user ID",
+			"result":      nil,
+		})
+	}
+
+	var req struct {
+		Password string `json:"password"`
+	}
+
+	if err := ctx.BodyParser(&req); err != nil || req.Password == "" {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     "Password is required to erase your account",
+			"result":      nil,
+		})
+	}
+
+	if err := c.usecase.EraseOwnAccount(userID, req.Password, ctx); err != nil {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Account erased successfully",
+		"result":      nil,
+	})
+}
+
+func (c *PrivacyController) EraseUserHandler(ctx *fiber.Ctx) error {
+	momID := ctx.Params("id")
+	if err := c.usecase.EraseUser(momID, ctx); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ctx.Status(fiber.ErrNotFound.Code).JSON(fiber.Map{
+				"status":      fiber.ErrNotFound.Message,
+				"status_code": fiber.ErrNotFound.Code,
+				"message":     err.Error(),
+				"result":      nil,
+			})
+		}
+
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "User erased successfully",
+		"result":      nil,
+	})
+}
@@ -252,7 +252,7 @@ func (c *QuizController) GetAllQuizHandler(ctx *fiber.Ctx) error {
 		})
 	}
 
-	data, err := c.usecase.GetAllQuiz()
+	data, err := c.usecase.GetAllQuiz(ctx.QueryBool("include_retired"))
 	if err != nil {
 		return ctx.Status(fiber.ErrNotFound.Code).JSON(fiber.Map{
 			"status":      fiber.ErrNotFound.Message,
@@ -321,7 +321,7 @@ func (c *QuizController) UpdateQuizByIDHandler(ctx *fiber.Ctx) error {
 	})
 }
 
-func (c *QuizController) DeleteQuizByIDHandler(ctx *fiber.Ctx) error {
+func (c *QuizController) RetireQuizByIDHandler(ctx *fiber.Ctx) error {
 	idParam := ctx.Params("id")
 	id, err := strconv.Atoi(idParam)
 	if err != nil {
@@ -343,11 +343,11 @@ func (c *QuizController) DeleteQuizByIDHandler(ctx *fiber.Ctx) error {
 		})
 	}
 
-	if err := c.usecase.DeleteQuizByID(id); err != nil {
-		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"status":      "Error",
-			"status_code": fiber.StatusInternalServerError,
-			"message":     "Something went wrong",
+	if err := c.usecase.RetireQuizByID(id); err != nil {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     err.Error(),
 			"result":      nil,
 		})
 	}
@@ -355,7 +355,152 @@ func (c *QuizController) DeleteQuizByIDHandler(ctx *fiber.Ctx) error {
 	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status":      "Success",
 		"status_code": fiber.StatusOK,
-		"message":     "Quiz deleted successfully",
+		"message":     "Quiz retired successfully",
 		"result":      nil,
 	})
 }
+
+func (c *QuizController) GetQuizVersionsHandler(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusBadRequest,
+			"message":     "Invalid quiz ID",
+			"result":      nil,
+		})
+	}
+
+	data, err := c.usecase.GetQuizVersions(id)
+	if err != nil {
+		return ctx.Status(fiber.ErrInternalServerError.Code).JSON(fiber.Map{
+			"status":      fiber.ErrInternalServerError.Message,
+			"status_code": fiber.ErrInternalServerError.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Quiz versions retrieved successfully",
+		"result":      data,
+	})
+}
+
+func (c *QuizController) GetQuizVersionHandler(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusBadRequest,
+			"message":     "Invalid quiz ID",
+			"result":      nil,
+		})
+	}
+
+	version, err := strconv.Atoi(ctx.Params("version"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusBadRequest,
+			"message":     "Invalid quiz version",
+			"result":      nil,
+		})
+	}
+
+	data, err := c.usecase.GetQuizVersion(id, version)
+	if err != nil {
+		return ctx.Status(fiber.ErrNotFound.Code).JSON(fiber.Map{
+			"status":      fiber.ErrNotFound.Message,
+			"status_code": fiber.ErrNotFound.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Quiz version retrieved successfully",
+		"result":      data,
+	})
+}
+
+func (c *QuizController) ImportQuizzesHandler(ctx *fiber.Ctx) error {
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusBadRequest,
+			"message":     "ZIP file is required",
+			"result":      nil,
+		})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return ctx.Status(fiber.ErrInternalServerError.Code).JSON(fiber.Map{
+			"status":      fiber.ErrInternalServerError.Message,
+			"status_code": fiber.ErrInternalServerError.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+	defer file.Close()
+
+	results, err := c.usecase.ImportQuizzes(file, fileHeader.Size, ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusBadRequest,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	counts := map[string]int{"created": 0, "updated": 0, "unchanged": 0, "error": 0}
+	for _, result := range results {
+		counts[result.Status]++
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Quiz import processed",
+		"result": fiber.Map{
+			"created":   counts["created"],
+			"updated":   counts["updated"],
+			"unchanged": counts["unchanged"],
+			"failed":    counts["error"],
+			"rows":      results,
+		},
+	})
+}
+
+func (c *QuizController) ExportQuizzesHandler(ctx *fiber.Ctx) error {
+	format := ctx.Query("format", "json")
+	if format != "json" && format != "csv" {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     "format must be json or csv",
+			"result":      nil,
+		})
+	}
+
+	data, err := c.usecase.ExportQuizzes(format)
+	if err != nil {
+		return ctx.Status(fiber.ErrInternalServerError.Code).JSON(fiber.Map{
+			"status":      fiber.ErrInternalServerError.Message,
+			"status_code": fiber.ErrInternalServerError.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	ctx.Set(fiber.HeaderContentType, "application/zip")
+	ctx.Set(fiber.HeaderContentDisposition, `attachment; filename="quizzes-`+format+`.zip"`)
+	return ctx.Status(fiber.StatusOK).Send(data)
+}
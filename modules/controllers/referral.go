@@ -0,0 +1,109 @@
+package controllers
+
+import (
+	"Beside-Mom-BE/modules/usecases"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ReferralController struct {
+	usecase usecases.ReferralUseCase
+}
+
+func NewReferralController(usecase usecases.ReferralUseCase) *ReferralController {
+	return &ReferralController{usecase: usecase}
+}
+
+func (c *ReferralController) GetReferralsHandler(ctx *fiber.Ctx) error {
+	assigneeID := ctx.Query("assignee")
+	if assigneeID == "me" {
+		assigneeID, _ = ctx.Locals("user_id").(string)
+	}
+
+	data, err := c.usecase.GetReferrals(ctx.Query("state"), assigneeID)
+	if err != nil {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Referrals retrieved successfully",
+		"result":      data,
+	})
+}
+
+func (c *ReferralController) GetReferralByIDHandler(ctx *fiber.Ctx) error {
+	data, err := c.usecase.GetReferralByID(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.ErrNotFound.Code).JSON(fiber.Map{
+			"status":      fiber.ErrNotFound.Message,
+			"status_code": fiber.ErrNotFound.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Referral retrieved successfully",
+		"result":      data,
+	})
+}
+
+func (c *ReferralController) UpdateReferralHandler(ctx *fiber.Ctx) error {
+	var req struct {
+		State         string  `json:"state"`
+		AssigneeID    string  `json:"assignee_id"`
+		AppointmentID string  `json:"appointment_id"`
+		RetestDueAt   string  `json:"retest_due_at"`
+		Note          *string `json:"note"`
+	}
+
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	var retestDueAt *time.Time
+	if req.RetestDueAt != "" {
+		date, err := time.Parse("2006-01-02", req.RetestDueAt)
+		if err != nil {
+			return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+				"status":      fiber.ErrBadRequest.Message,
+				"status_code": fiber.ErrBadRequest.Code,
+				"message":     "Invalid retest_due_at format. Use YYYY-MM-DD",
+				"result":      nil,
+			})
+		}
+		retestDueAt = &date
+	}
+
+	data, err := c.usecase.UpdateReferral(ctx.Params("id"), req.State, req.AssigneeID, req.AppointmentID, retestDueAt, req.Note, ctx)
+	if err != nil {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Referral updated successfully",
+		"result":      data,
+	})
+}
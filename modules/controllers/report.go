@@ -0,0 +1,31 @@
+package controllers
+
+import (
+	"Beside-Mom-BE/modules/usecases"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ReportController struct {
+	usecase usecases.ReportUseCase
+}
+
+func NewReportController(usecase usecases.ReportUseCase) *ReportController {
+	return &ReportController{usecase: usecase}
+}
+
+func (c *ReportController) GetKidReportHandler(ctx *fiber.Ctx) error {
+	data, err := c.usecase.GetKidReport(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.ErrInternalServerError.Code).JSON(fiber.Map{
+			"status":      fiber.ErrInternalServerError.Message,
+			"status_code": fiber.ErrInternalServerError.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	ctx.Set(fiber.HeaderContentType, "application/pdf")
+	ctx.Set(fiber.HeaderContentDisposition, `inline; filename="health-booklet.pdf"`)
+	return ctx.Status(fiber.StatusOK).Send(data)
+}
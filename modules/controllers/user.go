@@ -346,35 +346,6 @@ func (c *UserController) UpdateUserByIDForAdminHandler(ctx *fiber.Ctx) error {
 	})
 }
 
-func (c *UserController) DeleteUserHandler(ctx *fiber.Ctx) error {
-	momID := ctx.Params("id")
-	userID, ok := ctx.Locals("user_id").(string)
-	if !ok || userID == "" {
-		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"status":      "Error",
-			"status_code": fiber.StatusUnauthorized,
-			"message":     "Unauthorized: Missing user ID",
-			"result":      nil,
-		})
-	}
-
-	err := c.usecase.DeleteUser(momID)
-	if err != nil {
-		return ctx.Status(fiber.ErrNotFound.Code).JSON(fiber.Map{
-			"status":      fiber.ErrNotFound.Message,
-			"status_code": fiber.ErrNotFound.Code,
-			"message":     err.Error(),
-			"result":      nil,
-		})
-	}
-
-	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
-		"status":      "Success",
-		"status_code": fiber.StatusOK,
-		"message":     "User deleted successfully",
-	})
-}
-
 func (c *UserController) ChatBotHandler(ctx *fiber.Ctx) error {
 	userID, ok := ctx.Locals("user_id").(string)
 	if !ok || userID == "" {
@@ -202,7 +202,7 @@ func (c *VideoController) GetVideoByIDHandler(ctx *fiber.Ctx) error {
 	}
 
 	role, ok := ctx.Locals("role").(string)
-	if !ok || (role != "User" && role != "Admin") {
+	if !ok || role == "" {
 		return ctx.Status(fiber.StatusForbidden).JSON(fiber.Map{
 			"status":      "Error",
 			"status_code": fiber.StatusForbidden,
@@ -0,0 +1,15 @@
+package entities
+
+import "time"
+
+type GrowthAlert struct {
+	ID        string    `json:"alert_id" gorm:"primaryKey"`
+	KidID     string    `json:"kid_id" gorm:"not null;index"`
+	GrowthID  string    `json:"growth_id" gorm:"not null;index"`
+	Rule      string    `json:"rule" gorm:"not null"`
+	Severity  string    `json:"severity" gorm:"not null;index"`
+	Message   string    `json:"message" gorm:"not null"`
+	Kid       Kid       `json:"kid" gorm:"foreignKey:KidID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Growth    Growth    `json:"-" gorm:"foreignKey:GrowthID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	CreatedAt time.Time `json:"created_at"`
+}
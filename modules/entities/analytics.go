@@ -0,0 +1,62 @@
+package entities
+
+import "time"
+
+type AnalyticsFilter struct {
+	From *time.Time
+	To   *time.Time
+}
+
+type ScreeningRate struct {
+	PeriodID    int     `json:"period_id"`
+	Period      string  `json:"period"`
+	CategoryID  int     `json:"category_id"`
+	Category    string  `json:"category"`
+	Total       int     `json:"total"`
+	Passed      int     `json:"passed"`
+	Failed      int     `json:"failed"`
+	Pending     int     `json:"pending"`
+	PassRate    float64 `json:"pass_rate"`
+	FailRate    float64 `json:"fail_rate"`
+	PendingRate float64 `json:"pending_rate"`
+}
+
+type FailedQuiz struct {
+	QuizID     int     `json:"quiz_id"`
+	Question   string  `json:"question"`
+	PeriodID   int     `json:"period_id"`
+	Period     string  `json:"period"`
+	CategoryID int     `json:"category_id"`
+	Category   string  `json:"category"`
+	Attempts   int     `json:"attempts"`
+	Failures   int     `json:"failures"`
+	FailRate   float64 `json:"fail_rate"`
+}
+
+type AttemptsToPass struct {
+	PeriodID     int     `json:"period_id"`
+	Period       string  `json:"period"`
+	CategoryID   int     `json:"category_id"`
+	Category     string  `json:"category"`
+	Passed       int     `json:"passed"`
+	AverageTimes float64 `json:"average_times"`
+}
+
+type CohortTrend struct {
+	Cohort   string  `json:"cohort"`
+	Kids     int     `json:"kids"`
+	Total    int     `json:"total"`
+	Passed   int     `json:"passed"`
+	Failed   int     `json:"failed"`
+	Pending  int     `json:"pending"`
+	PassRate float64 `json:"pass_rate"`
+}
+
+type ScreeningAnalytics struct {
+	From           *time.Time       `json:"from"`
+	To             *time.Time       `json:"to"`
+	Rates          []ScreeningRate  `json:"rates"`
+	MostFailed     []FailedQuiz     `json:"most_failed_quizzes"`
+	AttemptsToPass []AttemptsToPass `json:"attempts_to_pass"`
+	Cohorts        []CohortTrend    `json:"cohorts"`
+}
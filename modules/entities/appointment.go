@@ -12,6 +12,7 @@ type Appointment struct {
 	Doctor      string    `json:"doctor" gorm:"not null"`
 	Status      int       `json:"status" gorm:"not null"`
 	UserID      string    `json:"user_id" gorm:"not null;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	KidID       *string   `json:"kid_id" gorm:"index"`
 	User        User      `json:"user" gorm:"foreignKey:UserID;references:ID"`
 	CreatedAt   time.Time `json:"created_at"`
 }
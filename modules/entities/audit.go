@@ -0,0 +1,28 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AuditLog struct {
+	ID        string          `json:"audit_id" gorm:"primaryKey"`
+	ActorID   string          `json:"actor_id" gorm:"index"`
+	ActorRole string          `json:"actor_role"`
+	Action    string          `json:"action" gorm:"not null"`
+	Entity    string          `json:"entity" gorm:"not null;index"`
+	EntityID  string          `json:"entity_id" gorm:"not null;index"`
+	Before    json.RawMessage `json:"before" gorm:"type:jsonb"`
+	After     json.RawMessage `json:"after" gorm:"type:jsonb"`
+	IP        string          `json:"ip"`
+	CreatedAt time.Time       `json:"created_at" gorm:"index"`
+}
+
+type AuditFilter struct {
+	ActorID  string
+	Action   string
+	Entity   string
+	EntityID string
+	From     *time.Time
+	To       *time.Time
+}
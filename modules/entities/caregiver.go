@@ -0,0 +1,17 @@
+package entities
+
+import "time"
+
+type KidCaregiver struct {
+	ID        string     `json:"kc_id" gorm:"primaryKey"`
+	KidID     string     `json:"kid_id" gorm:"not null;index"`
+	UserID    string     `json:"user_id" gorm:"not null;index"`
+	Access    string     `json:"access" gorm:"not null"`
+	Relation  string     `json:"relation"`
+	InvitedBy string     `json:"invited_by" gorm:"not null"`
+	RevokedAt *time.Time `json:"revoked_at"`
+	Kid       Kid        `json:"-" gorm:"foreignKey:KidID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	User      User       `json:"user" gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
@@ -1,6 +1,11 @@
 package entities
 
+import "time"
+
 type Category struct {
-	ID       int    `gorm:"primaryKey;autoIncrement"`
-	Category string `json:"category" gorm:"not null"`
+	ID        int        `gorm:"primaryKey;autoIncrement"`
+	Category  string     `json:"category" gorm:"not null"`
+	MinPassed int        `json:"min_passed" gorm:"not null;default:0"`
+	SortOrder int        `json:"sort_order" gorm:"not null;default:0"`
+	RetiredAt *time.Time `json:"retired_at"`
 }
@@ -2,13 +2,34 @@ package entities
 
 import "time"
 
+const (
+	EvaluateStateUpcoming  = "upcoming"
+	EvaluateStateDue       = "due"
+	EvaluateStateOverdue   = "overdue"
+	EvaluateStateCompleted = "completed"
+)
+
+const (
+	ResultPending    = "pending"
+	ResultInProgress = "in_progress"
+	ResultPassed     = "passed"
+	ResultFailed     = "failed"
+)
+
 type Evaluate struct {
 	ID             string     `json:"E_id" gorm:"primaryKey"`
 	Status         bool       `json:"status" gorm:"not null"`
-	Solution       string     `json:"solution_status" gorm:"not null"`
+	Result         string     `json:"result" gorm:"not null;default:pending"`
+	Solution       string     `json:"solution_status" gorm:"-"`
 	EvaluatedTimes int        `json:"evaluate_times" gorm:"not null"`
+	Sequence       int        `json:"sequence" gorm:"-"`
 	PeriodID       int        `json:"period_id" gorm:"not null"`
 	KidID          string     `json:"-" gorm:"not null"`
+	DueFrom        *time.Time `json:"due_from" gorm:"type:date"`
+	DueTo          *time.Time `json:"due_to" gorm:"type:date"`
+	State          string     `json:"state" gorm:"-"`
+	Period         Period     `json:"period" gorm:"foreignKey:PeriodID;references:ID"`
+	Kid            Kid        `json:"-" gorm:"foreignKey:KidID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 	CompletedAt    *time.Time `json:"completed_at"`
 	CreatedAt      time.Time  `json:"created_at"`
 	UpdatedAt      time.Time  `json:"updated_at"`
@@ -3,11 +3,22 @@ package entities
 import "time"
 
 type Growth struct {
-	ID        string    `json:"G_id" gorm:"primaryKey"`
-	Length    float64   `json:"length" gorm:"not null"`
-	Weight    float64   `json:"weight" gorm:"not null"`
-	Months    int       `json:"months" gorm:"not null"`
-	KidID     string    `json:"-" gorm:"not null"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID                string    `json:"G_id" gorm:"primaryKey"`
+	Length            float64   `json:"length" gorm:"not null"`
+	Weight            float64   `json:"weight" gorm:"not null"`
+	HeadCircumference *float64  `json:"head_circumference"`
+	Months            int       `json:"months" gorm:"not null"`
+	CorrectedMonths   int       `json:"corrected_months" gorm:"not null;default:0"`
+	KidID             string    `json:"-" gorm:"not null"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+type GrowthImportResult struct {
+	Row      int    `json:"row"`
+	KidID    string `json:"kid_id"`
+	Date     string `json:"date"`
+	Status   string `json:"status"`
+	GrowthID string `json:"growth_id,omitempty"`
+	Message  string `json:"message,omitempty"`
 }
@@ -3,20 +3,46 @@ package entities
 import "time"
 
 type History struct {
-	ID             string    `json:"H_id" gorm:"primaryKey"`
-	QuizID         int       `json:"quiz_id" gorm:"not null"`
-	Answer         bool      `json:"answer" gorm:"not null"`
-	Status         bool      `json:"status" gorm:"not null"`
-	EvaluatedTimes int       `json:"evaluate_times" gorm:"not null"`
-	Times          int       `json:"done_times" gorm:"not null"`
-	KidID          string    `json:"-" gorm:"not null"`
-	Quiz           Quiz      `json:"quiz" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
-	CreatedAt      time.Time `json:"created_at"`
-	UpdatedAt      time.Time `json:"updated_at"`
+	ID             string       `json:"H_id" gorm:"primaryKey"`
+	QuizID         int          `json:"quiz_id" gorm:"not null"`
+	Answer         bool         `json:"answer" gorm:"not null"`
+	Status         bool         `json:"status" gorm:"not null"`
+	EvaluatedTimes int          `json:"evaluate_times" gorm:"not null"`
+	Times          int          `json:"done_times" gorm:"not null"`
+	KidID          string       `json:"-" gorm:"not null"`
+	Note           string       `json:"note"`
+	MediaLink      string       `json:"media_link"`
+	MediaType      string       `json:"media_type"`
+	QuizVersionID  *int         `json:"quiz_version_id"`
+	MinPassed      *int         `json:"min_passed"`
+	Quiz           Quiz         `json:"quiz" gorm:"constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;"`
+	QuizVersion    *QuizVersion `json:"-" gorm:"foreignKey:QuizVersionID;references:ID"`
+	CreatedAt      time.Time    `json:"created_at"`
+	UpdatedAt      time.Time    `json:"updated_at"`
 }
 
 type GroupedHistory struct {
 	Histories []History
+	Result    string `json:"result"`
 	Solution  string `json:"solution_status"`
 	DoneAt    *time.Time
 }
+
+type HistorySyncItem struct {
+	KidID          string `json:"kid_id"`
+	EvaluatedTimes int    `json:"evaluate_times"`
+	QuizID         int    `json:"quiz_id"`
+	HistoryID      string `json:"history_id,omitempty"`
+	Reason         string `json:"reason"`
+}
+
+type HistorySyncReport struct {
+	DryRun          bool              `json:"dry_run"`
+	OpenEvaluations int               `json:"open_evaluations"`
+	Missing         []HistorySyncItem `json:"missing"`
+	Orphaned        []HistorySyncItem `json:"orphaned"`
+	Skipped         []HistorySyncItem `json:"skipped"`
+	StartedAt       time.Time         `json:"started_at"`
+	FinishedAt      time.Time         `json:"finished_at"`
+	Error           string            `json:"error,omitempty"`
+}
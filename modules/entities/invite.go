@@ -0,0 +1,14 @@
+package entities
+
+import "time"
+
+type Invite struct {
+	ID         string     `json:"i_id" gorm:"primaryKey"`
+	UserID     string     `json:"user_id" gorm:"not null;index"`
+	ExpiresAt  time.Time  `json:"expires_at" gorm:"not null"`
+	AcceptedAt *time.Time `json:"accepted_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	User       User       `json:"user" gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
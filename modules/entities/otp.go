@@ -3,8 +3,13 @@ package entities
 import "time"
 
 type OTP struct {
-	UserID    string    `json:"-" gorm:"primaryKey"`
-	OTP       string    `json:"otp" gorm:"not null;unique"`
-	ExpiresAt time.Time `json:"expires_at" gorm:"not null"`
-	User      User      `json:"-" gorm:"foreignKey:UserID;references:ID"`
+	UserID         string     `json:"-" gorm:"primaryKey"`
+	OTP            string     `json:"-" gorm:"not null"`
+	ExpiresAt      time.Time  `json:"expires_at" gorm:"not null"`
+	IssuedAt       time.Time  `json:"-"`
+	Attempts       int        `json:"attempts" gorm:"not null;default:0"`
+	LockedUntil    *time.Time `json:"locked_until"`
+	ResetToken     string     `json:"-"`
+	ResetExpiresAt *time.Time `json:"-"`
+	User           User       `json:"-" gorm:"foreignKey:UserID;references:ID"`
 }
@@ -1,6 +1,12 @@
 package entities
 
+import "time"
+
 type Period struct {
-	ID     int    `gorm:"primaryKey;autoIncrement"`
-	Period string `json:"period" gorm:"not null"`
+	ID          int        `gorm:"primaryKey;autoIncrement"`
+	Period      string     `json:"period" gorm:"not null"`
+	StartMonths int        `json:"start_months" gorm:"not null;default:0"`
+	EndMonths   int        `json:"end_months" gorm:"not null;default:0"`
+	SortOrder   int        `json:"sort_order" gorm:"not null;default:0"`
+	RetiredAt   *time.Time `json:"retired_at"`
 }
@@ -0,0 +1,7 @@
+package entities
+
+type Permission struct {
+	ID          int    `json:"p_id" gorm:"primaryKey"`
+	Name        string `json:"name" gorm:"not null;unique"`
+	Description string `json:"description"`
+}
@@ -0,0 +1,13 @@
+package entities
+
+type UserExport struct {
+	User         User
+	Kids         []Kid
+	Growths      []Growth
+	Evaluates    []Evaluate
+	Histories    []History
+	Appointments []Appointment
+	Likes        []Likes
+	Caregivers   []KidCaregiver
+	Referrals    []Referral
+}
@@ -3,7 +3,28 @@ package entities
 import "time"
 
 type Quiz struct {
-	ID          int       `json:"quiz_id" gorm:"primaryKey;autoIncrement"`
+	ID          int        `json:"quiz_id" gorm:"primaryKey;autoIncrement"`
+	ExternalKey *string    `json:"external_key" gorm:"uniqueIndex"`
+	Question    string     `json:"question" gorm:"not null"`
+	Description string     `json:"desc" gorm:"not null"`
+	Solution    string     `json:"solution" gorm:"not null"`
+	Suggestion  string     `json:"suggestion" gorm:"not null"`
+	Banner      string     `json:"banner" gorm:"not null"`
+	BannerHash  string     `json:"-"`
+	CategoryID  int        `json:"category_id" gorm:"not null"`
+	PeriodID    int        `json:"period_id" gorm:"not null"`
+	Version     int        `json:"version" gorm:"not null;default:1"`
+	RetiredAt   *time.Time `json:"retired_at"`
+	Category    Category   `json:"category" gorm:"foreignKey:CategoryID;references:ID;"`
+	Period      Period     `json:"period" gorm:"foreignKey:PeriodID;references:ID;"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+type QuizVersion struct {
+	ID          int       `json:"quiz_version_id" gorm:"primaryKey;autoIncrement"`
+	QuizID      int       `json:"quiz_id" gorm:"not null;uniqueIndex:idx_quiz_version"`
+	Version     int       `json:"version" gorm:"not null;uniqueIndex:idx_quiz_version"`
 	Question    string    `json:"question" gorm:"not null"`
 	Description string    `json:"desc" gorm:"not null"`
 	Solution    string    `json:"solution" gorm:"not null"`
@@ -11,8 +32,26 @@ type Quiz struct {
 	Banner      string    `json:"banner" gorm:"not null"`
 	CategoryID  int       `json:"category_id" gorm:"not null"`
 	PeriodID    int       `json:"period_id" gorm:"not null"`
-	Category    Category  `json:"category" gorm:"foreignKey:CategoryID;references:ID;"`
-	Period      Period    `json:"period" gorm:"foreignKey:PeriodID;references:ID;"`
+	PublishedBy string    `json:"published_by"`
 	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type QuizManifestItem struct {
+	ExternalKey string `json:"external_key"`
+	Question    string `json:"question"`
+	Description string `json:"desc"`
+	Solution    string `json:"solution"`
+	Suggestion  string `json:"suggestion"`
+	CategoryID  int    `json:"category_id"`
+	PeriodID    int    `json:"period_id"`
+	Banner      string `json:"banner"`
+}
+
+type QuizImportResult struct {
+	Row         int    `json:"row"`
+	ExternalKey string `json:"external_key"`
+	Status      string `json:"status"`
+	QuizID      int    `json:"quiz_id,omitempty"`
+	Version     int    `json:"version,omitempty"`
+	Message     string `json:"message,omitempty"`
 }
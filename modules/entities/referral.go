@@ -0,0 +1,30 @@
+package entities
+
+import "time"
+
+const (
+	ReferralStateOpen     = "open"
+	ReferralStateRetested = "retested"
+	ReferralStateReferred = "referred"
+	ReferralStateClosed   = "closed"
+)
+
+type Referral struct {
+	ID             string       `json:"rf_id" gorm:"primaryKey"`
+	KidID          string       `json:"kid_id" gorm:"not null;index"`
+	EvaluatedTimes int          `json:"evaluate_times" gorm:"not null"`
+	CategoryID     int          `json:"category_id" gorm:"not null"`
+	State          string       `json:"state" gorm:"not null;index"`
+	RetestDueAt    time.Time    `json:"retest_due_at" gorm:"type:date;not null"`
+	RetestPassed   *bool        `json:"retest_passed"`
+	AssigneeID     *string      `json:"assignee_id" gorm:"index"`
+	AppointmentID  *string      `json:"appointment_id"`
+	Note           string       `json:"note"`
+	ClosedAt       *time.Time   `json:"closed_at"`
+	Kid            Kid          `json:"kid" gorm:"foreignKey:KidID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Category       Category     `json:"category" gorm:"foreignKey:CategoryID;references:ID"`
+	Assignee       *User        `json:"assignee" gorm:"foreignKey:AssigneeID;references:ID;constraint:OnDelete:SET NULL;"`
+	Appointment    *Appointment `json:"appointment" gorm:"foreignKey:AppointmentID;references:ID;constraint:OnDelete:SET NULL;"`
+	CreatedAt      time.Time    `json:"created_at"`
+	UpdatedAt      time.Time    `json:"updated_at"`
+}
@@ -1,6 +1,7 @@
 package entities
 
 type Role struct {
-	ID       int    `json:"r_id" gorm:"primaryKey"`
-	RoleName string `json:"role"`
+	ID          int          `json:"r_id" gorm:"primaryKey"`
+	RoleName    string       `json:"role"`
+	Permissions []Permission `json:"permissions,omitempty" gorm:"many2many:role_permissions;"`
 }
@@ -0,0 +1,16 @@
+package entities
+
+import "time"
+
+type Session struct {
+	ID           string     `json:"s_id" gorm:"primaryKey"`
+	UserID       string     `json:"user_id" gorm:"not null;index"`
+	RefreshToken string     `json:"-" gorm:"not null;unique"`
+	UserAgent    string     `json:"user_agent"`
+	IP           string     `json:"ip"`
+	ExpiresAt    time.Time  `json:"expires_at" gorm:"not null"`
+	RevokedAt    *time.Time `json:"revoked_at"`
+	User         User       `json:"-" gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,58 @@
+package repositories
+
+import (
+	"Beside-Mom-BE/modules/entities"
+
+	"gorm.io/gorm"
+)
+
+type GormGrowthAlertRepository struct {
+	db *gorm.DB
+}
+
+func NewGormGrowthAlertRepository(db *gorm.DB) *GormGrowthAlertRepository {
+	return &GormGrowthAlertRepository{db: db}
+}
+
+type GrowthAlertRepository interface {
+	ReplaceAlertsForGrowth(growthID string, alerts []entities.GrowthAlert) error
+	GetAlerts(severity string) ([]entities.GrowthAlert, error)
+	GetAlertsByKidID(kidID string) ([]entities.GrowthAlert, error)
+}
+
+func (r *GormGrowthAlertRepository) ReplaceAlertsForGrowth(growthID string, alerts []entities.GrowthAlert) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("growth_id = ?", growthID).Delete(&entities.GrowthAlert{}).Error; err != nil {
+			return err
+		}
+
+		if len(alerts) == 0 {
+			return nil
+		}
+
+		return tx.Create(&alerts).Error
+	})
+}
+
+func (r *GormGrowthAlertRepository) GetAlerts(severity string) ([]entities.GrowthAlert, error) {
+	var alerts []entities.GrowthAlert
+	query := r.db.Preload("Kid")
+	if severity != "" {
+		query = query.Where("severity = ?", severity)
+	}
+
+	if err := query.Order("created_at desc").Find(&alerts).Error; err != nil {
+		return nil, err
+	}
+
+	return alerts, nil
+}
+
+func (r *GormGrowthAlertRepository) GetAlertsByKidID(kidID string) ([]entities.GrowthAlert, error) {
+	var alerts []entities.GrowthAlert
+	if err := r.db.Where("kid_id = ?", kidID).Order("created_at desc").Find(&alerts).Error; err != nil {
+		return nil, err
+	}
+
+	return alerts, nil
+}
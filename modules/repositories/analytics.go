@@ -0,0 +1,173 @@
+package repositories
+
+import (
+	"Beside-Mom-BE/modules/entities"
+
+	"gorm.io/gorm"
+)
+
+type GormAnalyticsRepository struct {
+	db *gorm.DB
+}
+
+func NewGormAnalyticsRepository(db *gorm.DB) *GormAnalyticsRepository {
+	return &GormAnalyticsRepository{db: db}
+}
+
+type AnalyticsRepository interface {
+	GetScreeningRates(filter entities.AnalyticsFilter) ([]entities.ScreeningRate, error)
+	GetMostFailedQuizzes(filter entities.AnalyticsFilter, limit int) ([]entities.FailedQuiz, error)
+	GetAttemptsToPass(filter entities.AnalyticsFilter) ([]entities.AttemptsToPass, error)
+	GetCohortTrends(filter entities.AnalyticsFilter) ([]entities.CohortTrend, error)
+}
+
+const screeningsCTE = `WITH latest AS (
+		SELECT DISTINCT ON (histories.kid_id, histories.evaluated_times, histories.quiz_id)
+			histories.kid_id, histories.evaluated_times, histories.quiz_id, histories.times, histories.answer,
+			histories.created_at, histories.min_passed
+		FROM histories
+		ORDER BY histories.kid_id, histories.evaluated_times, histories.quiz_id, histories.times DESC, histories.created_at DESC
+	), grouped AS (
+		SELECT latest.kid_id, latest.evaluated_times, quizzes.category_id, MIN(quizzes.period_id) AS period_id,
+			BOOL_OR(latest.times = 0) AS pending,
+			COUNT(*) FILTER (WHERE latest.times > 0) AS answered,
+			COUNT(*) FILTER (WHERE latest.times > 0 AND latest.answer) AS passed,
+			MAX(COALESCE(latest.min_passed, categories.min_passed)) AS min_passed,
+			MAX(latest.times) AS times,
+			MAX(latest.created_at) FILTER (WHERE latest.times > 0) AS done_at,
+			MIN(latest.created_at) AS created_at
+		FROM latest
+		JOIN quizzes ON quizzes.id = latest.quiz_id
+		JOIN categories ON categories.id = quizzes.category_id
+		GROUP BY latest.kid_id, latest.evaluated_times, quizzes.category_id
+	), screenings AS (
+		SELECT grouped.kid_id, grouped.evaluated_times, grouped.category_id, grouped.period_id, grouped.times,
+			CASE
+				WHEN grouped.pending THEN 'pending'
+				WHEN grouped.passed >= CASE WHEN grouped.min_passed > 0 AND grouped.min_passed < grouped.answered
+					THEN grouped.min_passed ELSE grouped.answered END THEN 'passed'
+				ELSE 'failed'
+			END AS result,
+			CASE WHEN grouped.pending THEN COALESCE(evaluates.due_from, grouped.created_at) ELSE grouped.done_at END AS screened_at
+		FROM grouped
+		LEFT JOIN evaluates ON evaluates.kid_id = grouped.kid_id AND evaluates.evaluated_times = grouped.evaluated_times
+	)`
+
+func (r *GormAnalyticsRepository) GetScreeningRates(filter entities.AnalyticsFilter) ([]entities.ScreeningRate, error) {
+	where, args := analyticsRange("screenings.screened_at", filter)
+	var rates []entities.ScreeningRate
+	if err := r.db.Raw(screeningsCTE+`
+		SELECT screenings.period_id, periods.period, screenings.category_id, categories.category,
+			COUNT(*) AS total,
+			COUNT(*) FILTER (WHERE screenings.result = 'passed') AS passed,
+			COUNT(*) FILTER (WHERE screenings.result = 'failed') AS failed,
+			COUNT(*) FILTER (WHERE screenings.result = 'pending') AS pending
+		FROM screenings
+		JOIN periods ON periods.id = screenings.period_id
+		JOIN categories ON categories.id = screenings.category_id
+		`+where+`
+		GROUP BY screenings.period_id, periods.period, screenings.category_id, categories.category
+		ORDER BY screenings.period_id, screenings.category_id`, args...).
+		Scan(&rates).Error; err != nil {
+		return nil, err
+	}
+
+	return rates, nil
+}
+
+func (r *GormAnalyticsRepository) GetMostFailedQuizzes(filter entities.AnalyticsFilter, limit int) ([]entities.FailedQuiz, error) {
+	where, args := analyticsRange("histories.created_at", filter)
+	if where == "" {
+		where = "WHERE histories.times > 0"
+	} else {
+		where += " AND histories.times > 0"
+	}
+
+	var quizzes []entities.FailedQuiz
+	if err := r.db.Raw(`SELECT histories.quiz_id, quizzes.question, quizzes.period_id, periods.period,
+			quizzes.category_id, categories.category,
+			COUNT(*) AS attempts,
+			COUNT(*) FILTER (WHERE NOT histories.answer) AS failures
+		FROM histories
+		JOIN quizzes ON quizzes.id = histories.quiz_id
+		JOIN periods ON periods.id = quizzes.period_id
+		JOIN categories ON categories.id = quizzes.category_id
+		`+where+`
+		GROUP BY histories.quiz_id, quizzes.question, quizzes.period_id, periods.period, quizzes.category_id, categories.category
+		HAVING COUNT(*) FILTER (WHERE NOT histories.answer) > 0
+		ORDER BY failures DESC, COUNT(*) FILTER (WHERE NOT histories.answer)::float / COUNT(*) DESC, histories.quiz_id
+		LIMIT ?`, append(args, limit)...).
+		Scan(&quizzes).Error; err != nil {
+		return nil, err
+	}
+
+	return quizzes, nil
+}
+
+func (r *GormAnalyticsRepository) GetAttemptsToPass(filter entities.AnalyticsFilter) ([]entities.AttemptsToPass, error) {
+	where, args := analyticsRange("screenings.screened_at", filter)
+	if where == "" {
+		where = "WHERE screenings.result = 'passed'"
+	} else {
+		where += " AND screenings.result = 'passed'"
+	}
+
+	var attempts []entities.AttemptsToPass
+	if err := r.db.Raw(screeningsCTE+`
+		SELECT screenings.period_id, periods.period, screenings.category_id, categories.category,
+			COUNT(*) AS passed,
+			ROUND(AVG(screenings.times)::numeric, 2)::float8 AS average_times
+		FROM screenings
+		JOIN periods ON periods.id = screenings.period_id
+		JOIN categories ON categories.id = screenings.category_id
+		`+where+`
+		GROUP BY screenings.period_id, periods.period, screenings.category_id, categories.category
+		ORDER BY screenings.period_id, screenings.category_id`, args...).
+		Scan(&attempts).Error; err != nil {
+		return nil, err
+	}
+
+	return attempts, nil
+}
+
+func (r *GormAnalyticsRepository) GetCohortTrends(filter entities.AnalyticsFilter) ([]entities.CohortTrend, error) {
+	where, args := analyticsRange("screenings.screened_at", filter)
+	var cohorts []entities.CohortTrend
+	if err := r.db.Raw(screeningsCTE+`
+		SELECT TO_CHAR(kids.birth_date, 'YYYY-MM') AS cohort,
+			COUNT(DISTINCT screenings.kid_id) AS kids,
+			COUNT(*) AS total,
+			COUNT(*) FILTER (WHERE screenings.result = 'passed') AS passed,
+			COUNT(*) FILTER (WHERE screenings.result = 'failed') AS failed,
+			COUNT(*) FILTER (WHERE screenings.result = 'pending') AS pending
+		FROM screenings
+		JOIN kids ON kids.id = screenings.kid_id
+		`+where+`
+		GROUP BY TO_CHAR(kids.birth_date, 'YYYY-MM')
+		ORDER BY cohort`, args...).
+		Scan(&cohorts).Error; err != nil {
+		return nil, err
+	}
+
+	return cohorts, nil
+}
+
+func analyticsRange(column string, filter entities.AnalyticsFilter) (string, []interface{}) {
+	where := ""
+	args := []interface{}{}
+	if filter.From != nil {
+		where = "WHERE " + column + " >= ?"
+		args = append(args, *filter.From)
+	}
+
+	if filter.To != nil {
+		if where == "" {
+			where = "WHERE " + column + " < ?"
+		} else {
+			where += " AND " + column + " < ?"
+		}
+		args = append(args, *filter.To)
+	}
+
+	return where, args
+}
@@ -2,6 +2,8 @@ package repositories
 
 import (
 	"Beside-Mom-BE/modules/entities"
+	"context"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -15,15 +17,22 @@ func NewGormAppRepository(db *gorm.DB) *GormAppRepository {
 }
 
 type AppRepository interface {
+	WithContext(ctx context.Context) AppRepository
 	CreateAppointment(app *entities.Appointment) (*entities.Appointment, error)
 	GetAppByID(id string) (*entities.Appointment, error)
 	GetAppByUserID(userID string) ([]entities.Appointment, error)
+	GetAppByUserIDWithShared(userID string) ([]entities.Appointment, error)
 	GetAppInProgressByUserID(userID string) ([]entities.Appointment, error)
 	GetAllApp() ([]entities.Appointment, error)
+	GetUpcomingAppByKidID(kidID string, from time.Time) ([]entities.Appointment, error)
 	UpdateAppByID(app *entities.Appointment) (*entities.Appointment, error)
 	DeleteAppByID(id string) error
 }
 
+func (r *GormAppRepository) WithContext(ctx context.Context) AppRepository {
+	return &GormAppRepository{db: r.db.WithContext(ctx)}
+}
+
 func (r *GormAppRepository) CreateAppointment(app *entities.Appointment) (*entities.Appointment, error) {
 	if err := r.db.Create(&app).Error; err != nil {
 		return nil, err
@@ -50,6 +59,19 @@ func (r *GormAppRepository) GetAppByUserID(userID string) ([]entities.Appointmen
 	return apps, nil
 }
 
+func (r *GormAppRepository) GetAppByUserIDWithShared(userID string) ([]entities.Appointment, error) {
+	var apps []entities.Appointment
+	sharedKids := r.db.Table("kid_caregivers").
+		Select("kid_id").
+		Where("user_id = ? AND revoked_at IS NULL", userID)
+
+	if err := r.db.Preload("User").Where("user_id = ? OR kid_id IN (?)", userID, sharedKids).Find(&apps).Error; err != nil {
+		return nil, err
+	}
+
+	return apps, nil
+}
+
 func (r *GormAppRepository) GetAllApp() ([]entities.Appointment, error) {
 	var apps []entities.Appointment
 	if err := r.db.Preload("User").Find(&apps).Error; err != nil {
@@ -68,6 +90,15 @@ func (r *GormAppRepository) GetAppInProgressByUserID(userID string) ([]entities.
 	return apps, nil
 }
 
+func (r *GormAppRepository) GetUpcomingAppByKidID(kidID string, from time.Time) ([]entities.Appointment, error) {
+	var apps []entities.Appointment
+	if err := r.db.Where("kid_id = ? AND date >= ?", kidID, from).Order("date, start_time").Find(&apps).Error; err != nil {
+		return nil, err
+	}
+
+	return apps, nil
+}
+
 func (r *GormAppRepository) UpdateAppByID(app *entities.Appointment) (*entities.Appointment, error) {
 	if err := r.db.Save(&app).Error; err != nil {
 		return nil, err
@@ -0,0 +1,58 @@
+package repositories
+
+import (
+	"Beside-Mom-BE/modules/entities"
+
+	"gorm.io/gorm"
+)
+
+type GormAuditRepository struct {
+	db *gorm.DB
+}
+
+func NewGormAuditRepository(db *gorm.DB) *GormAuditRepository {
+	return &GormAuditRepository{db: db}
+}
+
+type AuditRepository interface {
+	GetAuditLogs(filter entities.AuditFilter, page int, limit int) ([]entities.AuditLog, int64, error)
+}
+
+func (r *GormAuditRepository) GetAuditLogs(filter entities.AuditFilter, page int, limit int) ([]entities.AuditLog, int64, error) {
+	query := r.db.Model(&entities.AuditLog{})
+	if filter.ActorID != "" {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+
+	if filter.Entity != "" {
+		query = query.Where("entity = ?", filter.Entity)
+	}
+
+	if filter.EntityID != "" {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var logs []entities.AuditLog
+	if err := query.Order("created_at desc").Offset((page - 1) * limit).Limit(limit).Find(&logs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return logs, total, nil
+}
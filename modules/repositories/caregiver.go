@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"Beside-Mom-BE/modules/entities"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type GormCaregiverRepository struct {
+	db *gorm.DB
+}
+
+func NewGormCaregiverRepository(db *gorm.DB) *GormCaregiverRepository {
+	return &GormCaregiverRepository{db: db}
+}
+
+type CaregiverRepository interface {
+	CreateCaregiver(caregiver *entities.KidCaregiver) (*entities.KidCaregiver, error)
+	GetCaregiverByID(id string) (*entities.KidCaregiver, error)
+	GetActiveCaregiver(kidID string, userID string) (*entities.KidCaregiver, error)
+	GetCaregiversByKidID(kidID string) ([]entities.KidCaregiver, error)
+	GetSharedKidsByUserID(userID string) ([]entities.KidCaregiver, error)
+	UpdateCaregiver(caregiver *entities.KidCaregiver) (*entities.KidCaregiver, error)
+	RevokeCaregiver(id string) error
+}
+
+func (r *GormCaregiverRepository) CreateCaregiver(caregiver *entities.KidCaregiver) (*entities.KidCaregiver, error) {
+	if err := r.db.Omit("Kid", "User").Create(&caregiver).Error; err != nil {
+		return nil, err
+	}
+
+	return r.GetCaregiverByID(caregiver.ID)
+}
+
+func (r *GormCaregiverRepository) GetCaregiverByID(id string) (*entities.KidCaregiver, error) {
+	var caregiver entities.KidCaregiver
+	if err := r.db.Preload("User").Where("id = ?", id).First(&caregiver).Error; err != nil {
+		return nil, err
+	}
+
+	return &caregiver, nil
+}
+
+func (r *GormCaregiverRepository) GetActiveCaregiver(kidID string, userID string) (*entities.KidCaregiver, error) {
+	var caregiver entities.KidCaregiver
+	if err := r.db.Where("kid_id = ? AND user_id = ? AND revoked_at IS NULL", kidID, userID).First(&caregiver).Error; err != nil {
+		return nil, err
+	}
+
+	return &caregiver, nil
+}
+
+func (r *GormCaregiverRepository) GetCaregiversByKidID(kidID string) ([]entities.KidCaregiver, error) {
+	var caregivers []entities.KidCaregiver
+	if err := r.db.Preload("User").Where("kid_id = ? AND revoked_at IS NULL", kidID).Order("created_at").Find(&caregivers).Error; err != nil {
+		return nil, err
+	}
+
+	return caregivers, nil
+}
+
+func (r *GormCaregiverRepository) GetSharedKidsByUserID(userID string) ([]entities.KidCaregiver, error) {
+	var caregivers []entities.KidCaregiver
+	if err := r.db.Preload("Kid").Where("user_id = ? AND revoked_at IS NULL", userID).Order("created_at").Find(&caregivers).Error; err != nil {
+		return nil, err
+	}
+
+	return caregivers, nil
+}
+
+func (r *GormCaregiverRepository) UpdateCaregiver(caregiver *entities.KidCaregiver) (*entities.KidCaregiver, error) {
+	if err := r.db.Omit("Kid", "User").Save(&caregiver).Error; err != nil {
+		return nil, err
+	}
+
+	return r.GetCaregiverByID(caregiver.ID)
+}
+
+func (r *GormCaregiverRepository) RevokeCaregiver(id string) error {
+	return r.db.Model(&entities.KidCaregiver{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now()).Error
+}
@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"Beside-Mom-BE/modules/entities"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type GormCategoryRepository struct {
+	db *gorm.DB
+}
+
+func NewGormCategoryRepository(db *gorm.DB) *GormCategoryRepository {
+	return &GormCategoryRepository{db: db}
+}
+
+type CategoryRepository interface {
+	GetAllCategories(includeRetired bool) ([]entities.Category, error)
+	GetCategoryByID(id int) (*entities.Category, error)
+	CreateCategory(category *entities.Category) (*entities.Category, error)
+	RenameCategory(id int, name string) (*entities.Category, error)
+	UpdateCategoryRule(id int, minPassed int) (*entities.Category, error)
+	ReorderCategories(ids []int) error
+	RetireCategory(id int) error
+}
+
+func (r *GormCategoryRepository) GetAllCategories(includeRetired bool) ([]entities.Category, error) {
+	var categories []entities.Category
+	query := r.db.Order("sort_order, id")
+	if !includeRetired {
+		query = query.Where("retired_at IS NULL")
+	}
+
+	if err := query.Find(&categories).Error; err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+func (r *GormCategoryRepository) GetCategoryByID(id int) (*entities.Category, error) {
+	var category entities.Category
+	if err := r.db.Where("id = ?", id).First(&category).Error; err != nil {
+		return nil, err
+	}
+
+	return &category, nil
+}
+
+func (r *GormCategoryRepository) CreateCategory(category *entities.Category) (*entities.Category, error) {
+	var maxOrder int
+	if err := r.db.Model(&entities.Category{}).Select("COALESCE(MAX(sort_order), 0)").Scan(&maxOrder).Error; err != nil {
+		return nil, err
+	}
+
+	category.SortOrder = maxOrder + 1
+	if err := r.db.Create(&category).Error; err != nil {
+		return nil, err
+	}
+
+	return r.GetCategoryByID(category.ID)
+}
+
+func (r *GormCategoryRepository) RenameCategory(id int, name string) (*entities.Category, error) {
+	if err := r.db.Model(&entities.Category{}).Where("id = ?", id).Update("category", name).Error; err != nil {
+		return nil, err
+	}
+
+	return r.GetCategoryByID(id)
+}
+
+func (r *GormCategoryRepository) UpdateCategoryRule(id int, minPassed int) (*entities.Category, error) {
+	if err := r.db.Model(&entities.Category{}).Where("id = ?", id).Update("min_passed", minPassed).Error; err != nil {
+		return nil, err
+	}
+
+	return r.GetCategoryByID(id)
+}
+
+func (r *GormCategoryRepository) ReorderCategories(ids []int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for i, id := range ids {
+			if err := tx.Model(&entities.Category{}).Where("id = ?", id).Update("sort_order", i+1).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *GormCategoryRepository) RetireCategory(id int) error {
+	return r.db.Model(&entities.Category{}).Where("id = ?", id).Update("retired_at", time.Now()).Error
+}
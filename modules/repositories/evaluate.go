@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"Beside-Mom-BE/modules/entities"
+	"context"
 	"time"
 
 	"gorm.io/gorm"
@@ -16,9 +17,20 @@ func NewGormEvaluateRepository(db *gorm.DB) *GormEvaluateRepository {
 }
 
 type EvaluateRepository interface {
+	WithContext(ctx context.Context) EvaluateRepository
+	WithTx(tx *gorm.DB) EvaluateRepository
 	GetEvaluateByID(id string) (*entities.Evaluate, error)
 	GetAllEvaluate(kidID string) ([]entities.Evaluate, error)
-	UpdateEvaluate(evaluatedTimes int, kidID string, solution string, status bool) error
+	GetOverdueEvaluates(date time.Time) ([]entities.Evaluate, error)
+	UpdateEvaluate(evaluatedTimes int, kidID string, result string, status bool) error
+}
+
+func (r *GormEvaluateRepository) WithContext(ctx context.Context) EvaluateRepository {
+	return &GormEvaluateRepository{db: r.db.WithContext(ctx)}
+}
+
+func (r *GormEvaluateRepository) WithTx(tx *gorm.DB) EvaluateRepository {
+	return &GormEvaluateRepository{db: tx}
 }
 
 func (r *GormEvaluateRepository) GetEvaluateByID(id string) (*entities.Evaluate, error) {
@@ -32,17 +44,29 @@ func (r *GormEvaluateRepository) GetEvaluateByID(id string) (*entities.Evaluate,
 
 func (r *GormEvaluateRepository) GetAllEvaluate(kidID string) ([]entities.Evaluate, error) {
 	var evaluate []entities.Evaluate
-	if err := r.db.Where("kid_id = ?", kidID).Order("evaluated_times").Find(&evaluate).Error; err != nil {
+	if err := r.db.Preload("Period").Joins("JOIN periods ON periods.id = evaluates.period_id").
+		Where("evaluates.kid_id = ?", kidID).Order("periods.sort_order, periods.id").Find(&evaluate).Error; err != nil {
+		return nil, err
+	}
+
+	return evaluate, nil
+}
+
+func (r *GormEvaluateRepository) GetOverdueEvaluates(date time.Time) ([]entities.Evaluate, error) {
+	var evaluate []entities.Evaluate
+	if err := r.db.Preload("Period").Preload("Kid").
+		Where("status = ? AND due_to < ?", false, date).
+		Order("kid_id, evaluated_times").Find(&evaluate).Error; err != nil {
 		return nil, err
 	}
 
 	return evaluate, nil
 }
 
-func (r *GormEvaluateRepository) UpdateEvaluate(evaluatedTimes int, kidID string, solution string, status bool) error {
+func (r *GormEvaluateRepository) UpdateEvaluate(evaluatedTimes int, kidID string, result string, status bool) error {
 	return r.db.Model(&entities.Evaluate{}).Where("evaluated_times = ? AND kid_id = ?", evaluatedTimes, kidID).
 		Updates(map[string]interface{}{
-			"solution":     solution,
+			"result":       result,
 			"status":       status,
 			"completed_at": time.Now(),
 		}).Error
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"Beside-Mom-BE/modules/entities"
+	"context"
 	"sort"
 
 	"gorm.io/gorm"
@@ -16,14 +17,20 @@ func NewGormGrowthRepository(db *gorm.DB) *GormGrowthRepository {
 }
 
 type GrowthRepository interface {
+	WithContext(ctx context.Context) GrowthRepository
 	CreateGrowth(growth *entities.Growth) (*entities.Growth, error)
 	GetGrowthByID(id string) (*entities.Growth, error)
 	GetAllGrowth(kidID string) ([]entities.Growth, error)
+	GetAllGrowthRecords() ([]entities.Growth, error)
 	GetLatestGrowthByKidID(kidID string, month int) (*entities.Growth, error)
 	GetSummary(kidID string) ([]map[string]interface{}, error)
 	UpdateGrowth(growth *entities.Growth) (*entities.Growth, error)
 }
 
+func (r *GormGrowthRepository) WithContext(ctx context.Context) GrowthRepository {
+	return &GormGrowthRepository{db: r.db.WithContext(ctx)}
+}
+
 func (r *GormGrowthRepository) CreateGrowth(growth *entities.Growth) (*entities.Growth, error) {
 	if err := r.db.Create(growth).Error; err != nil {
 		return nil, err
@@ -50,6 +57,15 @@ func (r *GormGrowthRepository) GetAllGrowth(kidID string) ([]entities.Growth, er
 	return growth, nil
 }
 
+func (r *GormGrowthRepository) GetAllGrowthRecords() ([]entities.Growth, error) {
+	var growth []entities.Growth
+	if err := r.db.Order("kid_id, created_at").Find(&growth).Error; err != nil {
+		return nil, err
+	}
+
+	return growth, nil
+}
+
 func (r *GormGrowthRepository) GetLatestGrowthByKidID(kidID string, month int) (*entities.Growth, error) {
 	var growth *entities.Growth
 	if err := r.db.Where("kid_id = ? AND months = ?", kidID, month).Order("created_at desc").First(&growth).Error; err != nil {
@@ -86,17 +102,21 @@ func (r *GormGrowthRepository) GetSummary(kidID string) ([]map[string]interface{
 			hasMonth0 = true
 		}
 		summary = append(summary, map[string]interface{}{
-			"length": g.Length,
-			"weight": g.Weight,
-			"months": g.Months,
+			"length":             g.Length,
+			"weight":             g.Weight,
+			"head_circumference": g.HeadCircumference,
+			"months":             g.Months,
+			"date":               g.CreatedAt,
 		})
 	}
 
 	if !hasMonth0 {
 		summary = append(summary, map[string]interface{}{
-			"length": kid.BirthLength,
-			"weight": kid.BirthWeight,
-			"months": 0,
+			"length":             kid.BirthLength,
+			"weight":             kid.BirthWeight,
+			"head_circumference": (*float64)(nil),
+			"months":             0,
+			"date":               kid.BirthDate,
 		})
 	}
 
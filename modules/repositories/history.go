@@ -2,8 +2,11 @@ package repositories
 
 import (
 	"Beside-Mom-BE/modules/entities"
+	"Beside-Mom-BE/pkg/utils"
+	"context"
 	"time"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -16,15 +19,47 @@ func NewGormHistoryRepository(db *gorm.DB) *GormHistoryRepository {
 }
 
 type HistoryRepository interface {
+	WithContext(ctx context.Context) HistoryRepository
+	WithTx(tx *gorm.DB) HistoryRepository
+	Transaction(fn func(tx *gorm.DB) error) error
 	CreateHistory(history entities.History) error
 	GetHistoryPerQuizGroupedByCategoryAndTimes(times int, kidID string) (map[string]map[int]entities.GroupedHistory, error)
 	GetLatestHistoryPerEvaluate(times int, kidID string) ([]entities.History, error)
 	GetLatestHistoryPerQuiz(times int, cate int, kidID string) ([]entities.History, error)
 	GetHistoryResult(evaluatedTimes int, kidID string) (map[int]entities.GroupedHistory, error)
 	DeleteHistoryWithTimes(evaluatedTimes int, kidID string, times int, cate int) error
+	SubmitHistories(evaluatedTimes int, kidID string, cate int, histories []entities.History) error
+	SyncPlaceholderHistories(dryRun bool) (*entities.HistorySyncReport, error)
+}
+
+func (r *GormHistoryRepository) WithContext(ctx context.Context) HistoryRepository {
+	return &GormHistoryRepository{db: r.db.WithContext(ctx)}
+}
+
+func (r *GormHistoryRepository) WithTx(tx *gorm.DB) HistoryRepository {
+	return &GormHistoryRepository{db: tx}
+}
+
+func (r *GormHistoryRepository) Transaction(fn func(tx *gorm.DB) error) error {
+	return r.db.Transaction(fn)
 }
 
 func (r *GormHistoryRepository) CreateHistory(history entities.History) error {
+	if history.QuizVersionID == nil && history.Times > 0 {
+		var versionID int
+		if err := r.db.Model(&entities.QuizVersion{}).
+			Select("quiz_versions.id").
+			Joins("JOIN quizzes ON quizzes.id = quiz_versions.quiz_id AND quizzes.version = quiz_versions.version").
+			Where("quiz_versions.quiz_id = ?", history.QuizID).
+			Scan(&versionID).Error; err != nil {
+			return err
+		}
+
+		if versionID != 0 {
+			history.QuizVersionID = &versionID
+		}
+	}
+
 	return r.db.Create(&history).Error
 }
 
@@ -82,12 +117,16 @@ func (r *GormHistoryRepository) GetHistoryPerQuizGroupedByCategoryAndTimes(times
 	if err := r.db.
 		Joins("JOIN quizzes ON quizzes.id = histories.quiz_id").
 		Joins("JOIN categories ON categories.id = quizzes.category_id").
-		Preload("Quiz.Category").
+		Preload("Quiz.Category").Preload("QuizVersion").
 		Where("histories.evaluated_times = ? AND histories.kid_id = ?", times, kidID).
 		Find(&histories).Error; err != nil {
 		return nil, err
 	}
 
+	if err := applyQuizVersions(r.db, histories); err != nil {
+		return nil, err
+	}
+
 	result := make(map[string]map[int]entities.GroupedHistory)
 
 	for _, h := range histories {
@@ -115,24 +154,22 @@ func (r *GormHistoryRepository) GetHistoryPerQuizGroupedByCategoryAndTimes(times
 				g.DoneAt = &latest
 			}
 
-			allPassed := true
-			hasDone := false
+			passed, done := 0, 0
 			for _, h := range g.Histories {
 				if h.Times > 0 {
-					hasDone = true
-					if !h.Answer {
-						allPassed = false
-						break
+					done++
+					if h.Answer {
+						passed++
 					}
 				}
 			}
 
-			if !hasDone {
-				g.Solution = "รอประเมิน"
-			} else if allPassed {
-				g.Solution = "ผ่าน"
+			if done == 0 {
+				g.Result = entities.ResultPending
+			} else if utils.CategoryPassed(passed, done, submittedMinPassed(g.Histories)) {
+				g.Result = entities.ResultPassed
 			} else {
-				g.Solution = "ไม่ผ่าน"
+				g.Result = entities.ResultFailed
 			}
 
 			groupByTimes[times] = g
@@ -148,12 +185,16 @@ func (r *GormHistoryRepository) GetHistoryResult(evaluatedTimes int, kidID strin
 	if err := r.db.
 		Joins("JOIN quizzes ON quizzes.id = histories.quiz_id").
 		Joins("JOIN categories ON categories.id = quizzes.category_id").
-		Preload("Quiz.Category").Preload("Quiz.Period").
+		Preload("Quiz.Category").Preload("Quiz.Period").Preload("QuizVersion").
 		Where("histories.evaluated_times = ? AND histories.kid_id = ?", evaluatedTimes, kidID).
 		Find(&histories).Error; err != nil {
 		return nil, err
 	}
 
+	if err := applyQuizVersions(r.db, histories); err != nil {
+		return nil, err
+	}
+
 	latestHistories := make(map[int]entities.History)
 	for _, h := range histories {
 		if existing, found := latestHistories[h.QuizID]; !found || h.Times > existing.Times || h.CreatedAt.After(existing.CreatedAt) {
@@ -173,11 +214,11 @@ func (r *GormHistoryRepository) GetHistoryResult(evaluatedTimes int, kidID strin
 			Histories: hs,
 		}
 
-		allPassed := true
+		passed := 0
 		var latestTime *time.Time
 		for i, h := range hs {
-			if !h.Answer {
-				allPassed = false
+			if h.Answer {
+				passed++
 			}
 			if i == 0 || h.CreatedAt.After(*latestTime) {
 				t := h.CreatedAt
@@ -185,9 +226,9 @@ func (r *GormHistoryRepository) GetHistoryResult(evaluatedTimes int, kidID strin
 			}
 		}
 
-		group.Solution = "ผ่าน"
-		if !allPassed {
-			group.Solution = "ไม่ผ่าน"
+		group.Result = entities.ResultPassed
+		if !utils.CategoryPassed(passed, len(hs), submittedMinPassed(hs)) {
+			group.Result = entities.ResultFailed
 		}
 		group.DoneAt = latestTime
 
@@ -213,3 +254,243 @@ func (r *GormHistoryRepository) DeleteHistoryWithTimes(evaluatedTimes int, kidID
 		Where("evaluated_times = ? AND kid_id = ? AND times = ? AND quiz_id IN ?", evaluatedTimes, kidID, times, quizIDs).
 		Delete(&entities.History{}).Error
 }
+
+func (r *GormHistoryRepository) SubmitHistories(evaluatedTimes int, kidID string, cate int, histories []entities.History) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		repo := &GormHistoryRepository{db: tx}
+		if err := repo.DeleteHistoryWithTimes(evaluatedTimes, kidID, 0, cate); err != nil {
+			return err
+		}
+
+		for _, history := range histories {
+			if err := repo.CreateHistory(history); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *GormHistoryRepository) SyncPlaceholderHistories(dryRun bool) (*entities.HistorySyncReport, error) {
+	report := &entities.HistorySyncReport{
+		DryRun:    dryRun,
+		Missing:   []entities.HistorySyncItem{},
+		Orphaned:  []entities.HistorySyncItem{},
+		Skipped:   []entities.HistorySyncItem{},
+		StartedAt: time.Now(),
+	}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var evaluates []entities.Evaluate
+		if err := tx.Where("status = ?", false).Find(&evaluates).Error; err != nil {
+			return err
+		}
+		report.OpenEvaluations = len(evaluates)
+
+		var quizzes []entities.Quiz
+		if err := tx.Select("id", "period_id", "category_id", "retired_at").Order("id").Find(&quizzes).Error; err != nil {
+			return err
+		}
+
+		var retiredCategories []int
+		if err := tx.Model(&entities.Category{}).Where("retired_at IS NOT NULL").Pluck("id", &retiredCategories).Error; err != nil {
+			return err
+		}
+
+		retired := make(map[int]bool, len(retiredCategories))
+		for _, id := range retiredCategories {
+			retired[id] = true
+		}
+
+		quizPeriods := make(map[int]int, len(quizzes))
+		quizzesByPeriod := make(map[int][]int)
+		retiredQuizzes := make(map[int]bool)
+		for _, q := range quizzes {
+			if q.RetiredAt != nil {
+				retiredQuizzes[q.ID] = true
+				continue
+			}
+
+			quizPeriods[q.ID] = q.PeriodID
+			if !retired[q.CategoryID] {
+				quizzesByPeriod[q.PeriodID] = append(quizzesByPeriod[q.PeriodID], q.ID)
+			}
+		}
+
+		var histories []entities.History
+		if err := tx.Select("histories.id", "histories.kid_id", "histories.quiz_id", "histories.evaluated_times", "histories.times").
+			Joins("JOIN evaluates ON evaluates.kid_id = histories.kid_id AND evaluates.evaluated_times = histories.evaluated_times").
+			Where("evaluates.status = ?", false).
+			Find(&histories).Error; err != nil {
+			return err
+		}
+
+		type evaluateKey struct {
+			kidID string
+			times int
+		}
+
+		present := make(map[evaluateKey]map[int]bool)
+		for _, h := range histories {
+			key := evaluateKey{kidID: h.KidID, times: h.EvaluatedTimes}
+			if present[key] == nil {
+				present[key] = make(map[int]bool)
+			}
+			present[key][h.QuizID] = true
+		}
+
+		periods := make(map[evaluateKey]int, len(evaluates))
+		var created []entities.History
+		for _, eval := range evaluates {
+			key := evaluateKey{kidID: eval.KidID, times: eval.EvaluatedTimes}
+			periods[key] = eval.PeriodID
+			for _, quizID := range quizzesByPeriod[eval.PeriodID] {
+				if present[key][quizID] {
+					continue
+				}
+
+				report.Missing = append(report.Missing, entities.HistorySyncItem{
+					KidID:          eval.KidID,
+					EvaluatedTimes: eval.EvaluatedTimes,
+					QuizID:         quizID,
+					Reason:         "quiz has no placeholder in this evaluation",
+				})
+				created = append(created, entities.History{
+					ID:             uuid.New().String(),
+					QuizID:         quizID,
+					Answer:         false,
+					Status:         false,
+					EvaluatedTimes: eval.EvaluatedTimes,
+					Times:          0,
+					KidID:          eval.KidID,
+				})
+			}
+		}
+
+		var removed []string
+		for _, h := range histories {
+			period, exists := quizPeriods[h.QuizID]
+			if exists && period == periods[evaluateKey{kidID: h.KidID, times: h.EvaluatedTimes}] {
+				continue
+			}
+
+			item := entities.HistorySyncItem{
+				KidID:          h.KidID,
+				EvaluatedTimes: h.EvaluatedTimes,
+				QuizID:         h.QuizID,
+				HistoryID:      h.ID,
+				Reason:         "quiz was moved to another period",
+			}
+			if retiredQuizzes[h.QuizID] {
+				item.Reason = "quiz was retired"
+			} else if !exists {
+				item.Reason = "quiz no longer exists"
+			}
+
+			if h.Times > 0 {
+				item.Reason += "; history already answered"
+				report.Skipped = append(report.Skipped, item)
+				continue
+			}
+
+			report.Orphaned = append(report.Orphaned, item)
+			removed = append(removed, h.ID)
+		}
+
+		if dryRun {
+			return nil
+		}
+
+		if len(created) > 0 {
+			if err := tx.CreateInBatches(&created, 500).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(removed) > 0 {
+			if err := tx.Where("id IN ?", removed).Delete(&entities.History{}).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	report.FinishedAt = time.Now()
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func submittedMinPassed(histories []entities.History) int {
+	for _, h := range histories {
+		if h.Times > 0 && h.MinPassed != nil {
+			return *h.MinPassed
+		}
+	}
+
+	return histories[0].Quiz.Category.MinPassed
+}
+
+func applyQuizVersions(db *gorm.DB, histories []entities.History) error {
+	var categoryIDs, periodIDs []int
+	for i := range histories {
+		version := histories[i].QuizVersion
+		if version == nil {
+			continue
+		}
+
+		quiz := &histories[i].Quiz
+		quiz.Version = version.Version
+		quiz.Question = version.Question
+		quiz.Description = version.Description
+		quiz.Solution = version.Solution
+		quiz.Suggestion = version.Suggestion
+		quiz.Banner = version.Banner
+		quiz.CategoryID = version.CategoryID
+		quiz.PeriodID = version.PeriodID
+		if quiz.Category.ID != 0 && quiz.Category.ID != quiz.CategoryID {
+			categoryIDs = append(categoryIDs, quiz.CategoryID)
+		}
+		if quiz.Period.ID != 0 && quiz.Period.ID != quiz.PeriodID {
+			periodIDs = append(periodIDs, quiz.PeriodID)
+		}
+	}
+
+	categories := make(map[int]entities.Category)
+	if len(categoryIDs) > 0 {
+		var found []entities.Category
+		if err := db.Where("id IN ?", categoryIDs).Find(&found).Error; err != nil {
+			return err
+		}
+		for _, category := range found {
+			categories[category.ID] = category
+		}
+	}
+
+	periods := make(map[int]entities.Period)
+	if len(periodIDs) > 0 {
+		var found []entities.Period
+		if err := db.Where("id IN ?", periodIDs).Find(&found).Error; err != nil {
+			return err
+		}
+		for _, period := range found {
+			periods[period.ID] = period
+		}
+	}
+
+	for i := range histories {
+		quiz := &histories[i].Quiz
+		if category, ok := categories[quiz.CategoryID]; ok && quiz.Category.ID != 0 {
+			quiz.Category = category
+		}
+		if period, ok := periods[quiz.PeriodID]; ok && quiz.Period.ID != 0 {
+			quiz.Period = period
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"Beside-Mom-BE/modules/entities"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type GormInviteRepository struct {
+	db *gorm.DB
+}
+
+func NewGormInviteRepository(db *gorm.DB) *GormInviteRepository {
+	return &GormInviteRepository{db: db}
+}
+
+type InviteRepository interface {
+	CreateInvite(invite *entities.Invite) (*entities.Invite, error)
+	GetInviteByID(id string) (*entities.Invite, error)
+	GetLatestInviteByUserID(userID string) (*entities.Invite, error)
+	GetAllLatestInvites() ([]entities.Invite, error)
+	UpdateInvite(invite *entities.Invite) (*entities.Invite, error)
+	RevokeOpenInvitesByUserID(userID string) (int64, error)
+}
+
+func (r *GormInviteRepository) CreateInvite(invite *entities.Invite) (*entities.Invite, error) {
+	if err := r.db.Create(&invite).Error; err != nil {
+		return nil, err
+	}
+
+	return r.GetInviteByID(invite.ID)
+}
+
+func (r *GormInviteRepository) GetInviteByID(id string) (*entities.Invite, error) {
+	var invite entities.Invite
+	if err := r.db.Preload("User.Role").Where("id = ?", id).First(&invite).Error; err != nil {
+		return nil, err
+	}
+
+	return &invite, nil
+}
+
+func (r *GormInviteRepository) GetLatestInviteByUserID(userID string) (*entities.Invite, error) {
+	var invite entities.Invite
+	if err := r.db.Preload("User.Role").Where("user_id = ?", userID).Order("created_at desc").First(&invite).Error; err != nil {
+		return nil, err
+	}
+
+	return &invite, nil
+}
+
+func (r *GormInviteRepository) GetAllLatestInvites() ([]entities.Invite, error) {
+	var invites []entities.Invite
+	latest := r.db.Model(&entities.Invite{}).Select("user_id, MAX(created_at) AS max_created_at").Group("user_id")
+	if err := r.db.
+		Joins("JOIN (?) AS latest ON invites.user_id = latest.user_id AND invites.created_at = latest.max_created_at", latest).
+		Preload("User.Role").
+		Order("invites.created_at desc").
+		Find(&invites).Error; err != nil {
+		return nil, err
+	}
+
+	return invites, nil
+}
+
+func (r *GormInviteRepository) UpdateInvite(invite *entities.Invite) (*entities.Invite, error) {
+	if err := r.db.Omit("User").Save(&invite).Error; err != nil {
+		return nil, err
+	}
+
+	return r.GetInviteByID(invite.ID)
+}
+
+func (r *GormInviteRepository) RevokeOpenInvitesByUserID(userID string) (int64, error) {
+	result := r.db.Model(&entities.Invite{}).
+		Where("user_id = ? AND accepted_at IS NULL AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
@@ -2,6 +2,8 @@ package repositories
 
 import (
 	"Beside-Mom-BE/modules/entities"
+	"Beside-Mom-BE/pkg/utils"
+	"context"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -16,13 +18,19 @@ func NewGormKidsRepository(db *gorm.DB) *GormKidsRepository {
 }
 
 type KidsRepository interface {
+	WithContext(ctx context.Context) KidsRepository
 	CreateKid(kid *entities.Kid) (*entities.Kid, error)
 	GetKidByID(id string) (*entities.Kid, error)
 	GetKidByIDForUser(id string) (*entities.Kid, error)
+	GetKidOwnerID(id string) (string, error)
 	UpdateKidByID(kid *entities.Kid) (*entities.Kid, error)
 	DeleteKidByID(id string) error
 }
 
+func (r *GormKidsRepository) WithContext(ctx context.Context) KidsRepository {
+	return &GormKidsRepository{db: r.db.WithContext(ctx)}
+}
+
 func (r *GormKidsRepository) CreateKid(kid *entities.Kid) (*entities.Kid, error) {
 	err := r.db.Transaction(func(tx *gorm.DB) error {
 		if err := tx.Create(&kid).Error; err != nil {
@@ -30,23 +38,27 @@ func (r *GormKidsRepository) CreateKid(kid *entities.Kid) (*entities.Kid, error)
 		}
 
 		var quizzes []entities.Quiz
-		if err := tx.Find(&quizzes).Error; err != nil {
+		activeCategories := tx.Model(&entities.Category{}).Select("id").Where("retired_at IS NULL")
+		if err := tx.Where("retired_at IS NULL AND category_id IN (?)", activeCategories).Find(&quizzes).Error; err != nil {
 			return err
 		}
 
 		var periods []entities.Period
-		if err := tx.Find(&periods).Error; err != nil {
+		if err := tx.Where("retired_at IS NULL").Order("sort_order, id").Find(&periods).Error; err != nil {
 			return err
 		}
 
-		for i, period := range periods {
+		for _, period := range periods {
+			dueFrom, dueTo := utils.ScreeningDueWindow(kid.BirthDate, kid.BeforeBirth, period.StartMonths, period.EndMonths)
 			eval := entities.Evaluate{
 				ID:             uuid.New().String(),
 				Status:         false,
-				Solution:       "รอประเมิน",
-				EvaluatedTimes: i + 1,
+				Result:         entities.ResultPending,
+				EvaluatedTimes: period.ID,
 				PeriodID:       period.ID,
 				KidID:          kid.ID,
+				DueFrom:        &dueFrom,
+				DueTo:          &dueTo,
 			}
 
 			if err := tx.Create(&eval).Error; err != nil {
@@ -111,8 +123,38 @@ func (r *GormKidsRepository) GetKidByIDForUser(id string) (*entities.Kid, error)
 	return &kid, nil
 }
 
+func (r *GormKidsRepository) GetKidOwnerID(id string) (string, error) {
+	var kid entities.Kid
+	if err := r.db.Select("id", "user_id").Where("id = ?", id).First(&kid).Error; err != nil {
+		return "", err
+	}
+
+	return kid.UserID, nil
+}
+
 func (r *GormKidsRepository) UpdateKidByID(kid *entities.Kid) (*entities.Kid, error) {
-	if err := r.db.Save(&kid).Error; err != nil {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&kid).Error; err != nil {
+			return err
+		}
+
+		var evaluates []entities.Evaluate
+		if err := tx.Preload("Period").Where("kid_id = ?", kid.ID).Find(&evaluates).Error; err != nil {
+			return err
+		}
+
+		for _, eval := range evaluates {
+			dueFrom, dueTo := utils.ScreeningDueWindow(kid.BirthDate, kid.BeforeBirth, eval.Period.StartMonths, eval.Period.EndMonths)
+			if err := tx.Model(&entities.Evaluate{}).Where("id = ?", eval.ID).
+				Updates(map[string]interface{}{"due_from": dueFrom, "due_to": dueTo}).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,156 @@
+package repositories
+
+import (
+	"Beside-Mom-BE/modules/entities"
+	"Beside-Mom-BE/pkg/utils"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type GormPeriodRepository struct {
+	db *gorm.DB
+}
+
+func NewGormPeriodRepository(db *gorm.DB) *GormPeriodRepository {
+	return &GormPeriodRepository{db: db}
+}
+
+type PeriodRepository interface {
+	WithContext(ctx context.Context) PeriodRepository
+	GetAllPeriods(includeRetired bool) ([]entities.Period, error)
+	GetPeriodByID(id int) (*entities.Period, error)
+	CreatePeriod(period *entities.Period) (*entities.Period, error)
+	UpdatePeriod(period *entities.Period) (*entities.Period, error)
+	ReorderPeriods(ids []int) error
+	RetirePeriod(id int) error
+}
+
+func (r *GormPeriodRepository) WithContext(ctx context.Context) PeriodRepository {
+	return &GormPeriodRepository{db: r.db.WithContext(ctx)}
+}
+
+func (r *GormPeriodRepository) GetAllPeriods(includeRetired bool) ([]entities.Period, error) {
+	var periods []entities.Period
+	query := r.db.Order("sort_order, id")
+	if !includeRetired {
+		query = query.Where("retired_at IS NULL")
+	}
+
+	if err := query.Find(&periods).Error; err != nil {
+		return nil, err
+	}
+
+	return periods, nil
+}
+
+func (r *GormPeriodRepository) GetPeriodByID(id int) (*entities.Period, error) {
+	var period entities.Period
+	if err := r.db.Where("id = ?", id).First(&period).Error; err != nil {
+		return nil, err
+	}
+
+	return &period, nil
+}
+
+func (r *GormPeriodRepository) CreatePeriod(period *entities.Period) (*entities.Period, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var maxOrder int
+		if err := tx.Model(&entities.Period{}).Select("COALESCE(MAX(sort_order), 0)").Scan(&maxOrder).Error; err != nil {
+			return err
+		}
+
+		period.SortOrder = maxOrder + 1
+		if err := tx.Create(&period).Error; err != nil {
+			return err
+		}
+
+		var kids []entities.Kid
+		if err := tx.Select("id", "birth_date", "before_birth").Find(&kids).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		for _, kid := range kids {
+			dueFrom, dueTo := utils.ScreeningDueWindow(kid.BirthDate, kid.BeforeBirth, period.StartMonths, period.EndMonths)
+			if dueTo.Before(today) {
+				continue
+			}
+
+			eval := entities.Evaluate{
+				ID:             uuid.New().String(),
+				Status:         false,
+				Result:         entities.ResultPending,
+				EvaluatedTimes: period.ID,
+				PeriodID:       period.ID,
+				KidID:          kid.ID,
+				DueFrom:        &dueFrom,
+				DueTo:          &dueTo,
+			}
+
+			if err := tx.Omit("Period", "Kid").Create(&eval).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetPeriodByID(period.ID)
+}
+
+func (r *GormPeriodRepository) UpdatePeriod(period *entities.Period) (*entities.Period, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&entities.Period{}).Where("id = ?", period.ID).Updates(map[string]interface{}{
+			"period":       period.Period,
+			"start_months": period.StartMonths,
+			"end_months":   period.EndMonths,
+		}).Error; err != nil {
+			return err
+		}
+
+		var evaluates []entities.Evaluate
+		if err := tx.Preload("Kid").Where("period_id = ? AND status = ?", period.ID, false).Find(&evaluates).Error; err != nil {
+			return err
+		}
+
+		for _, eval := range evaluates {
+			dueFrom, dueTo := utils.ScreeningDueWindow(eval.Kid.BirthDate, eval.Kid.BeforeBirth, period.StartMonths, period.EndMonths)
+			if err := tx.Model(&entities.Evaluate{}).Where("id = ?", eval.ID).
+				Updates(map[string]interface{}{"due_from": dueFrom, "due_to": dueTo}).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetPeriodByID(period.ID)
+}
+
+func (r *GormPeriodRepository) ReorderPeriods(ids []int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for i, id := range ids {
+			if err := tx.Model(&entities.Period{}).Where("id = ?", id).Update("sort_order", i+1).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *GormPeriodRepository) RetirePeriod(id int) error {
+	return r.db.Model(&entities.Period{}).Where("id = ?", id).Update("retired_at", time.Now()).Error
+}
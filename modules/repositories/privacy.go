@@ -0,0 +1,135 @@
+package repositories
+
+import (
+	"Beside-Mom-BE/modules/entities"
+	"Beside-Mom-BE/pkg/database"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type GormPrivacyRepository struct {
+	db *gorm.DB
+}
+
+func NewGormPrivacyRepository(db *gorm.DB) *GormPrivacyRepository {
+	return &GormPrivacyRepository{db: db}
+}
+
+type PrivacyRepository interface {
+	WithContext(ctx context.Context) PrivacyRepository
+	GetUserExport(userID string) (*entities.UserExport, error)
+	EraseUser(userID string) error
+}
+
+func (r *GormPrivacyRepository) WithContext(ctx context.Context) PrivacyRepository {
+	return &GormPrivacyRepository{db: r.db.WithContext(ctx)}
+}
+
+func (r *GormPrivacyRepository) GetUserExport(userID string) (*entities.UserExport, error) {
+	var export entities.UserExport
+	if err := r.db.Preload("Role").Where("id = ?", userID).First(&export.User).Error; err != nil {
+		return nil, err
+	}
+
+	if err := r.db.Where("user_id = ?", userID).Order("created_at").Find(&export.Kids).Error; err != nil {
+		return nil, err
+	}
+
+	kidIDs := make([]string, 0, len(export.Kids))
+	for _, kid := range export.Kids {
+		kidIDs = append(kidIDs, kid.ID)
+	}
+
+	if len(kidIDs) > 0 {
+		if err := r.db.Where("kid_id IN ?", kidIDs).Order("kid_id, months").Find(&export.Growths).Error; err != nil {
+			return nil, err
+		}
+
+		if err := r.db.Where("kid_id IN ?", kidIDs).Order("kid_id, evaluated_times").Find(&export.Evaluates).Error; err != nil {
+			return nil, err
+		}
+
+		if err := r.db.Preload("Quiz").Preload("QuizVersion").Where("kid_id IN ?", kidIDs).Order("kid_id, evaluated_times, created_at").Find(&export.Histories).Error; err != nil {
+			return nil, err
+		}
+		if err := applyQuizVersions(r.db, export.Histories); err != nil {
+			return nil, err
+		}
+
+		if err := r.db.Where("kid_id IN ?", kidIDs).Order("kid_id, created_at").Find(&export.Referrals).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.db.Where("user_id = ?", userID).Order("date").Find(&export.Appointments).Error; err != nil {
+		return nil, err
+	}
+
+	if err := r.db.Preload("Video").Where("user_id = ?", userID).Order("created_at").Find(&export.Likes).Error; err != nil {
+		return nil, err
+	}
+
+	if err := r.db.Where("user_id = ? OR kid_id IN ?", userID, kidIDs).Order("created_at").Find(&export.Caregivers).Error; err != nil {
+		return nil, err
+	}
+
+	return &export, nil
+}
+
+func scrubAuditLogs(tx *gorm.DB, entity string, ids interface{}) error {
+	return database.RedactAuditLogs(tx, entity, ids, "pdpa_erasure")
+}
+
+func (r *GormPrivacyRepository) EraseUser(userID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var kidIDs []string
+		if err := tx.Model(&entities.Kid{}).Where("user_id = ?", userID).Pluck("id", &kidIDs).Error; err != nil {
+			return err
+		}
+
+		if err := scrubAuditLogs(tx, "appointments", tx.Model(&entities.Appointment{}).Select("id").Where("user_id = ?", userID)); err != nil {
+			return err
+		}
+
+		if len(kidIDs) > 0 {
+			if err := scrubAuditLogs(tx, "kids", kidIDs); err != nil {
+				return err
+			}
+
+			for table, model := range map[string]interface{}{"referrals": &entities.Referral{}, "histories": &entities.History{}, "evaluates": &entities.Evaluate{}, "growths": &entities.Growth{}} {
+				if err := scrubAuditLogs(tx, table, tx.Model(model).Select("id").Where("kid_id IN ?", kidIDs)); err != nil {
+					return err
+				}
+			}
+
+			for _, model := range []interface{}{&entities.GrowthAlert{}, &entities.Referral{}, &entities.History{}, &entities.Evaluate{}, &entities.Growth{}, &entities.KidCaregiver{}} {
+				if err := tx.Where("kid_id IN ?", kidIDs).Delete(model).Error; err != nil {
+					return err
+				}
+			}
+
+			if err := tx.Where("id IN ?", kidIDs).Delete(&entities.Kid{}).Error; err != nil {
+				return err
+			}
+		}
+
+		for _, model := range []interface{}{&entities.KidCaregiver{}, &entities.Appointment{}, &entities.Likes{}, &entities.Session{}, &entities.OTP{}, &entities.Invite{}} {
+			if err := tx.Where("user_id = ?", userID).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&entities.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+			"p_id":        "erased-" + userID,
+			"firstname":   "",
+			"lastname":    "",
+			"email":       "erased-" + userID + "@erased.invalid",
+			"password":    "",
+			"image_link":  "",
+			"status":      "erased",
+			"disabled_at": time.Now(),
+		}).Error
+	})
+}
@@ -2,8 +2,10 @@ package repositories
 
 import (
 	"Beside-Mom-BE/modules/entities"
+	"context"
+	"strconv"
+	"time"
 
-	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -16,39 +18,44 @@ func NewGormQuizRepository(db *gorm.DB) *GormQuizRepository {
 }
 
 type QuizRepository interface {
-	CreateQuiz(quiz *entities.Quiz) (*entities.Quiz, error)
+	WithContext(ctx context.Context) QuizRepository
+	CreateQuiz(quiz *entities.Quiz, publishedBy string) (*entities.Quiz, error)
 	GetQuizByID(id int) (*entities.Quiz, error)
-	GetAllQuiz() ([]entities.Quiz, error)
+	GetQuizByExternalKey(key string) (*entities.Quiz, error)
+	GetAllQuiz(includeRetired bool) ([]entities.Quiz, error)
 	GetQuizByIDandPeriod(id int, period int, cate int) (*entities.Quiz, error)
 	GetQuizByCategoryandPeriod(period int, cate int) ([]entities.Quiz, error)
-	UpdateQuizByID(quiz *entities.Quiz) (*entities.Quiz, error)
-	DeleteQuizByID(id int) error
+	UpdateQuizByID(quiz *entities.Quiz, publishedBy string) (*entities.Quiz, error)
+	RetireQuizByID(id int) error
+	GetQuizVersions(quizID int) ([]entities.QuizVersion, error)
+	GetQuizVersion(quizID int, version int) (*entities.QuizVersion, error)
 }
 
-func (r *GormQuizRepository) CreateQuiz(quiz *entities.Quiz) (*entities.Quiz, error) {
-	if err := r.db.Create(&quiz).Error; err != nil {
-		return nil, err
-	}
-
-	var evaluates []entities.Evaluate
-	if err := r.db.Where("status = ? AND solution = ? AND period_id = ?", false, "รอประเมิน", quiz.PeriodID).Find(&evaluates).Error; err != nil {
-		return nil, err
-	}
+func (r *GormQuizRepository) WithContext(ctx context.Context) QuizRepository {
+	return &GormQuizRepository{db: r.db.WithContext(ctx)}
+}
 
-	for _, eval := range evaluates {
-		history := entities.History{
-			ID:             uuid.New().String(),
-			QuizID:         quiz.ID,
-			Answer:         false,
-			Status:         false,
-			EvaluatedTimes: eval.EvaluatedTimes,
-			Times:          0,
-			KidID:          eval.KidID,
+func (r *GormQuizRepository) CreateQuiz(quiz *entities.Quiz, publishedBy string) (*entities.Quiz, error) {
+	quiz.Version = 1
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&quiz).Error; err != nil {
+			return err
 		}
 
-		if err := r.db.Create(&history).Error; err != nil {
-			return nil, err
+		if quiz.ExternalKey == nil || *quiz.ExternalKey == "" {
+			key := "quiz-" + strconv.Itoa(quiz.ID)
+			quiz.ExternalKey = &key
+			if err := tx.Model(&entities.Quiz{}).Where("id = ?", quiz.ID).Update("external_key", key).Error; err != nil {
+				return err
+			}
 		}
+
+		version := quizVersionOf(quiz, publishedBy)
+		return tx.Create(&version).Error
+	})
+
+	if err != nil {
+		return nil, err
 	}
 
 	return r.GetQuizByID(quiz.ID)
@@ -63,9 +70,23 @@ func (r *GormQuizRepository) GetQuizByID(id int) (*entities.Quiz, error) {
 	return &quiz, nil
 }
 
-func (r *GormQuizRepository) GetAllQuiz() ([]entities.Quiz, error) {
+func (r *GormQuizRepository) GetQuizByExternalKey(key string) (*entities.Quiz, error) {
+	var quiz entities.Quiz
+	if err := r.db.Preload("Category").Preload("Period").First(&quiz, "external_key = ?", key).Error; err != nil {
+		return nil, err
+	}
+
+	return &quiz, nil
+}
+
+func (r *GormQuizRepository) GetAllQuiz(includeRetired bool) ([]entities.Quiz, error) {
 	var quizs []entities.Quiz
-	if err := r.db.Preload("Category").Preload("Period").Order("id").Find(&quizs).Error; err != nil {
+	query := r.db.Preload("Category").Preload("Period").Order("id")
+	if !includeRetired {
+		query = query.Where("retired_at IS NULL")
+	}
+
+	if err := query.Find(&quizs).Error; err != nil {
 		return nil, err
 	}
 
@@ -74,7 +95,7 @@ func (r *GormQuizRepository) GetAllQuiz() ([]entities.Quiz, error) {
 
 func (r *GormQuizRepository) GetQuizByIDandPeriod(id int, period int, cate int) (*entities.Quiz, error) {
 	var quiz *entities.Quiz
-	if err := r.db.Where("period_id = ? AND category_id = ?", period, cate).Order("id").Preload("Category").Preload("Period").First(&quiz, id).Error; err != nil {
+	if err := r.db.Where("period_id = ? AND category_id = ? AND retired_at IS NULL", period, cate).Order("id").Preload("Category").Preload("Period").First(&quiz, id).Error; err != nil {
 		return nil, err
 	}
 
@@ -83,32 +104,87 @@ func (r *GormQuizRepository) GetQuizByIDandPeriod(id int, period int, cate int)
 
 func (r *GormQuizRepository) GetQuizByCategoryandPeriod(period int, cate int) ([]entities.Quiz, error) {
 	var quiz []entities.Quiz
-	if err := r.db.Where("period_id = ? AND category_id = ?", period, cate).Order("id").Preload("Category").Preload("Period").Find(&quiz).Error; err != nil {
+	if err := r.db.Where("period_id = ? AND category_id = ? AND retired_at IS NULL", period, cate).Order("id").Preload("Category").Preload("Period").Find(&quiz).Error; err != nil {
 		return nil, err
 	}
 
 	return quiz, nil
 }
 
-func (r *GormQuizRepository) UpdateQuizByID(quiz *entities.Quiz) (*entities.Quiz, error) {
-	if err := r.db.
-		Model(&entities.Quiz{}).
-		Where("id = ?", quiz.ID).
-		Updates(map[string]interface{}{
-			"question":    quiz.Question,
-			"description": quiz.Description,
-			"solution":    quiz.Solution,
-			"suggestion":  quiz.Suggestion,
-			"category_id": quiz.CategoryID,
-			"period_id":   quiz.PeriodID,
-			"banner":      quiz.Banner,
-		}).Error; err != nil {
+func (r *GormQuizRepository) UpdateQuizByID(quiz *entities.Quiz, publishedBy string) (*entities.Quiz, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var current entities.Quiz
+		if err := tx.First(&current, "id = ?", quiz.ID).Error; err != nil {
+			return err
+		}
+
+		if current.Question == quiz.Question && current.Description == quiz.Description &&
+			current.Solution == quiz.Solution && current.Suggestion == quiz.Suggestion &&
+			current.Banner == quiz.Banner && current.CategoryID == quiz.CategoryID && current.PeriodID == quiz.PeriodID {
+			return nil
+		}
+
+		quiz.Version = current.Version + 1
+		if err := tx.Model(&entities.Quiz{}).
+			Where("id = ?", quiz.ID).
+			Updates(map[string]interface{}{
+				"question":    quiz.Question,
+				"description": quiz.Description,
+				"solution":    quiz.Solution,
+				"suggestion":  quiz.Suggestion,
+				"category_id": quiz.CategoryID,
+				"period_id":   quiz.PeriodID,
+				"banner":      quiz.Banner,
+				"banner_hash": quiz.BannerHash,
+				"version":     quiz.Version,
+			}).Error; err != nil {
+			return err
+		}
+
+		version := quizVersionOf(quiz, publishedBy)
+		return tx.Create(&version).Error
+	})
+
+	if err != nil {
 		return nil, err
 	}
 
 	return r.GetQuizByID(quiz.ID)
 }
 
-func (r *GormQuizRepository) DeleteQuizByID(id int) error {
-	return r.db.Where("id = ?", id).Delete(&entities.Quiz{}).Error
+func (r *GormQuizRepository) RetireQuizByID(id int) error {
+	return r.db.Model(&entities.Quiz{}).Where("id = ?", id).Update("retired_at", time.Now()).Error
+}
+
+func (r *GormQuizRepository) GetQuizVersions(quizID int) ([]entities.QuizVersion, error) {
+	var versions []entities.QuizVersion
+	if err := r.db.Where("quiz_id = ?", quizID).Order("version desc").Find(&versions).Error; err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+func (r *GormQuizRepository) GetQuizVersion(quizID int, version int) (*entities.QuizVersion, error) {
+	var quizVersion entities.QuizVersion
+	if err := r.db.First(&quizVersion, "quiz_id = ? AND version = ?", quizID, version).Error; err != nil {
+		return nil, err
+	}
+
+	return &quizVersion, nil
+}
+
+func quizVersionOf(quiz *entities.Quiz, publishedBy string) entities.QuizVersion {
+	return entities.QuizVersion{
+		QuizID:      quiz.ID,
+		Version:     quiz.Version,
+		Question:    quiz.Question,
+		Description: quiz.Description,
+		Solution:    quiz.Solution,
+		Suggestion:  quiz.Suggestion,
+		Banner:      quiz.Banner,
+		CategoryID:  quiz.CategoryID,
+		PeriodID:    quiz.PeriodID,
+		PublishedBy: publishedBy,
+	}
 }
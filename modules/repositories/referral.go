@@ -0,0 +1,121 @@
+package repositories
+
+import (
+	"Beside-Mom-BE/modules/entities"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type GormReferralRepository struct {
+	db *gorm.DB
+}
+
+func NewGormReferralRepository(db *gorm.DB) *GormReferralRepository {
+	return &GormReferralRepository{db: db}
+}
+
+type ReferralRepository interface {
+	WithContext(ctx context.Context) ReferralRepository
+	WithTx(tx *gorm.DB) ReferralRepository
+	CreateReferral(referral *entities.Referral) (*entities.Referral, error)
+	GetReferralByID(id string) (*entities.Referral, error)
+	GetActiveReferral(kidID string, evaluatedTimes int, categoryID int) (*entities.Referral, error)
+	GetReferrals(state string, assigneeID string) ([]entities.Referral, error)
+	UpdateReferral(referral *entities.Referral) (*entities.Referral, error)
+	GetLeastLoadedNurseID() (string, error)
+}
+
+func (r *GormReferralRepository) WithContext(ctx context.Context) ReferralRepository {
+	return &GormReferralRepository{db: r.db.WithContext(ctx)}
+}
+
+func (r *GormReferralRepository) WithTx(tx *gorm.DB) ReferralRepository {
+	return &GormReferralRepository{db: tx}
+}
+
+func (r *GormReferralRepository) CreateReferral(referral *entities.Referral) (*entities.Referral, error) {
+	if err := r.db.Omit("Kid", "Category", "Assignee", "Appointment").Create(&referral).Error; err != nil {
+		return nil, err
+	}
+
+	return r.GetReferralByID(referral.ID)
+}
+
+func (r *GormReferralRepository) GetReferralByID(id string) (*entities.Referral, error) {
+	var referral entities.Referral
+	if err := r.db.Preload("Kid").Preload("Category").Preload("Assignee").Preload("Appointment").
+		Where("id = ?", id).First(&referral).Error; err != nil {
+		return nil, err
+	}
+
+	return &referral, nil
+}
+
+func (r *GormReferralRepository) GetActiveReferral(kidID string, evaluatedTimes int, categoryID int) (*entities.Referral, error) {
+	var referral entities.Referral
+	if err := r.db.Where("kid_id = ? AND evaluated_times = ? AND category_id = ? AND state <> ?", kidID, evaluatedTimes, categoryID, entities.ReferralStateClosed).
+		Order("created_at desc").First(&referral).Error; err != nil {
+		return nil, err
+	}
+
+	return &referral, nil
+}
+
+func (r *GormReferralRepository) GetReferrals(state string, assigneeID string) ([]entities.Referral, error) {
+	var referrals []entities.Referral
+	query := r.db.Preload("Kid").Preload("Category").Preload("Assignee").Preload("Appointment")
+	if state != "" {
+		query = query.Where("state = ?", state)
+	}
+
+	if assigneeID != "" {
+		query = query.Where("assignee_id = ?", assigneeID)
+	}
+
+	if err := query.Order("retest_due_at, created_at").Find(&referrals).Error; err != nil {
+		return nil, err
+	}
+
+	return referrals, nil
+}
+
+func (r *GormReferralRepository) UpdateReferral(referral *entities.Referral) (*entities.Referral, error) {
+	if err := r.db.Model(&entities.Referral{}).
+		Where("id = ?", referral.ID).
+		Updates(map[string]interface{}{
+			"state":          referral.State,
+			"retest_due_at":  referral.RetestDueAt,
+			"retest_passed":  referral.RetestPassed,
+			"assignee_id":    referral.AssigneeID,
+			"appointment_id": referral.AppointmentID,
+			"note":           referral.Note,
+			"closed_at":      referral.ClosedAt,
+		}).Error; err != nil {
+		return nil, err
+	}
+
+	return r.GetReferralByID(referral.ID)
+}
+
+func (r *GormReferralRepository) GetLeastLoadedNurseID() (string, error) {
+	var nurseID string
+	openCases := r.db.Model(&entities.Referral{}).
+		Select("assignee_id, COUNT(*) AS total").
+		Where("state <> ?", entities.ReferralStateClosed).
+		Group("assignee_id")
+
+	err := r.db.Model(&entities.User{}).
+		Select("users.id").
+		Joins("JOIN roles ON roles.id = users.role_id").
+		Joins("LEFT JOIN (?) AS workload ON workload.assignee_id = users.id", openCases).
+		Where("roles.role_name = ? AND users.status = ?", "Nurse", "active").
+		Order("COALESCE(workload.total, 0), users.created_at").
+		Limit(1).
+		Scan(&nurseID).Error
+	if err != nil {
+		return "", err
+	}
+
+	return nurseID, nil
+}
@@ -0,0 +1,42 @@
+package repositories
+
+import (
+	"Beside-Mom-BE/modules/entities"
+
+	"gorm.io/gorm"
+)
+
+type GormRoleRepository struct {
+	db *gorm.DB
+}
+
+func NewGormRoleRepository(db *gorm.DB) *GormRoleRepository {
+	return &GormRoleRepository{db: db}
+}
+
+type RoleRepository interface {
+	GetAllRoles() ([]entities.Role, error)
+	GetPermissionsByRoleName(roleName string) ([]string, error)
+}
+
+func (r *GormRoleRepository) GetAllRoles() ([]entities.Role, error) {
+	var roles []entities.Role
+	if err := r.db.Preload("Permissions").Order("id").Find(&roles).Error; err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+func (r *GormRoleRepository) GetPermissionsByRoleName(roleName string) ([]string, error) {
+	var permissions []string
+	if err := r.db.Table("permissions").
+		Joins("JOIN role_permissions ON role_permissions.permission_id = permissions.id").
+		Joins("JOIN roles ON roles.id = role_permissions.role_id").
+		Where("roles.role_name = ?", roleName).
+		Pluck("permissions.name", &permissions).Error; err != nil {
+		return nil, err
+	}
+
+	return permissions, nil
+}
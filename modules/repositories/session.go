@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"Beside-Mom-BE/modules/entities"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type GormSessionRepository struct {
+	db *gorm.DB
+}
+
+func NewGormSessionRepository(db *gorm.DB) *GormSessionRepository {
+	return &GormSessionRepository{db: db}
+}
+
+type SessionRepository interface {
+	CreateSession(session *entities.Session) (*entities.Session, error)
+	GetSessionByID(id string) (*entities.Session, error)
+	GetSessionByRefreshToken(refreshToken string) (*entities.Session, error)
+	RevokeSession(id string) (int64, error)
+	RevokeAllSessionsByUserID(userID string) (int64, error)
+}
+
+func (r *GormSessionRepository) CreateSession(session *entities.Session) (*entities.Session, error) {
+	if err := r.db.Create(&session).Error; err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func (r *GormSessionRepository) GetSessionByID(id string) (*entities.Session, error) {
+	var session entities.Session
+	if err := r.db.Where("id = ?", id).First(&session).Error; err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+func (r *GormSessionRepository) GetSessionByRefreshToken(refreshToken string) (*entities.Session, error) {
+	var session entities.Session
+	if err := r.db.Where("refresh_token = ?", refreshToken).First(&session).Error; err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+func (r *GormSessionRepository) RevokeSession(id string) (int64, error) {
+	result := r.db.Model(&entities.Session{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (r *GormSessionRepository) RevokeAllSessionsByUserID(userID string) (int64, error) {
+	result := r.db.Model(&entities.Session{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
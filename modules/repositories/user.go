@@ -23,11 +23,13 @@ type UserRepository interface {
 	GetRoleByName(name string) (entities.Role, error)
 	GetMomByID(id string) (*entities.User, error)
 	GetAllMom() ([]entities.User, error)
+	GetAllStaff() ([]entities.User, error)
 	DeleteUser(id string) error
 	FindLatestUnnamedPID() (string, error)
 
 	CreateOTP(otp *entities.OTP) error
 	GetOTPByUserID(userID string) (*entities.OTP, error)
+	UpdateOTP(otp *entities.OTP) error
 	DeleteOTP(userID string) error
 }
 
@@ -78,11 +80,17 @@ func (r *GormUserRepository) GetRoleByName(name string) (entities.Role, error) {
 	return role, nil
 }
 
+func momScope(db *gorm.DB) *gorm.DB {
+	return db.
+		Where("users.role_id IN (SELECT id FROM roles WHERE role_name = ?)", "User").
+		Where("(EXISTS (SELECT 1 FROM kids WHERE kids.user_id = users.id) OR NOT EXISTS (SELECT 1 FROM kid_caregivers WHERE kid_caregivers.user_id = users.id))")
+}
+
 func (r *GormUserRepository) GetMomByID(id string) (*entities.User, error) {
 	var user entities.User
 	if err := r.db.Preload("Role").Preload("Kid.Growth", func(db *gorm.DB) *gorm.DB {
 		return db.Order("months")
-	}).Where("id = ? AND role_id = ?", id, 2).First(&user).Error; err != nil {
+	}).Scopes(momScope).Where("id = ?", id).First(&user).Error; err != nil {
 		return nil, err
 	}
 
@@ -91,7 +99,17 @@ func (r *GormUserRepository) GetMomByID(id string) (*entities.User, error) {
 
 func (r *GormUserRepository) GetAllMom() ([]entities.User, error) {
 	var users []entities.User
-	if err := r.db.Where("role_id = ?", 2).Preload("Role").Find(&users).Error; err != nil {
+	if err := r.db.Scopes(momScope).Preload("Role").Find(&users).Error; err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (r *GormUserRepository) GetAllStaff() ([]entities.User, error) {
+	var users []entities.User
+	staffRoles := r.db.Model(&entities.Role{}).Select("id").Where("role_name <> ?", "User")
+	if err := r.db.Where("role_id IN (?)", staffRoles).Preload("Role").Order("created_at").Find(&users).Error; err != nil {
 		return nil, err
 	}
 
@@ -115,6 +133,10 @@ func (r *GormUserRepository) GetOTPByUserID(userID string) (*entities.OTP, error
 	return &otp, nil
 }
 
+func (r *GormUserRepository) UpdateOTP(otp *entities.OTP) error {
+	return r.db.Save(otp).Error
+}
+
 func (r *GormUserRepository) DeleteOTP(userID string) error {
 	if err := r.db.Delete(&entities.OTP{}, "user_id = ?", userID).Error; err != nil {
 		return err
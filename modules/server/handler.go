@@ -15,7 +15,7 @@ import (
 	"gorm.io/gorm"
 )
 
-func SetupRoutes(app *fiber.App, jwt configs.JWT, supa configs.Supabase, mail configs.Mail, chat configs.Chat) {
+func SetupRoutes(app *fiber.App, historySync usecases.HistorySyncUseCase, jwt configs.JWT, supa configs.Supabase, mail configs.Mail, chat configs.Chat, report configs.Report) {
 	db := database.GetDB()
 	if db == nil {
 		log.Fatal("Failed to initialize database")
@@ -41,43 +41,89 @@ func SetupRoutes(app *fiber.App, jwt configs.JWT, supa configs.Supabase, mail co
 	})
 
 	setupAuthRoutes(app, db, jwt, mail)
+	setupAdminRoutes(app, db, jwt, mail)
+	setupAuditRoutes(app, db, jwt)
+	setupAnalyticsRoutes(app, db, jwt)
 	setupQuestRoutes(app, db, jwt)
-	setupHistoryRoutes(app, db, jwt)
+	setupHistoryRoutes(app, db, jwt, supa)
 	setupLikeRoutes(app, db, jwt)
 	setupAppointRoutes(app, db, jwt)
 	setupEvaluateRoutes(app, db, jwt)
+	setupReferralRoutes(app, db, jwt)
 	setupGrowthRoutes(app, db, jwt)
 	setupVideoRoutes(app, db, jwt, supa)
 	setupCareRoutes(app, db, jwt, supa)
-	setupKidRoutes(app, db, jwt, supa)
-	setupQuizRoutes(app, db, jwt, supa)
+	setupKidRoutes(app, db, jwt, supa, mail, report)
+	setupQuizRoutes(app, db, historySync, jwt, supa)
+	setupCategoryRoutes(app, db, jwt)
+	setupPeriodRoutes(app, db, jwt)
 	setupUserRoutes(app, db, jwt, supa, mail, chat)
 }
 
 func setupAuthRoutes(app *fiber.App, db *gorm.DB, jwt configs.JWT, mail configs.Mail) {
 	repository := repositories.NewGormUserRepository(db)
-	usecase := usecases.NewAuthUseCase(repository, jwt, mail)
+	sessionrepository := repositories.NewGormSessionRepository(db)
+	inviterepository := repositories.NewGormInviteRepository(db)
+	usecase := usecases.NewAuthUseCase(repository, sessionrepository, jwt, mail)
+	inviteusecase := usecases.NewInviteUseCase(inviterepository, repository, jwt, mail)
 	controller := controllers.NewAuthController(usecase)
+	invitecontroller := controllers.NewInviteController(inviteusecase)
 
 	authGroup := app.Group("/auth")
-	authGroup.Post("/register", controller.RegisterHandler)
 	authGroup.Post("/login", controller.LoginHandler)
+	authGroup.Post("/refresh", controller.RefreshTokenHandler)
+	authGroup.Post("/invite/accept", invitecontroller.AcceptInviteHandler)
+	authGroup.Post("/logout", middlewares.JWTMiddleware(jwt, db), controller.LogoutHandler)
+	authGroup.Delete("/sessions/user/:id", middlewares.JWTMiddleware(jwt, db), middlewares.RequirePermission("staff:manage"), controller.RevokeAllSessionsHandler)
 	authGroup.Post("/forgotpassword", controller.ForgotPasswordHandler)
 	authGroup.Post("/forgotpassword/otp", controller.VerifyOTPHandler)
 	authGroup.Put("/forgotpassword/changepassword", controller.ChangedPasswordHandler)
 }
 
+func setupAdminRoutes(app *fiber.App, db *gorm.DB, jwt configs.JWT, mail configs.Mail) {
+	repository := repositories.NewGormUserRepository(db)
+	sessionrepository := repositories.NewGormSessionRepository(db)
+	inviterepository := repositories.NewGormInviteRepository(db)
+	rolerepository := repositories.NewGormRoleRepository(db)
+	usecase := usecases.NewAdminUseCase(repository, sessionrepository, inviterepository, rolerepository, jwt, mail)
+	controller := controllers.NewAdminController(usecase)
+
+	adminGroup := app.Group("/admin", middlewares.JWTMiddleware(jwt, db), middlewares.RequirePermission("staff:manage"))
+	adminGroup.Post("/invite", controller.InviteStaffHandler)
+	adminGroup.Get("/", controller.GetAllStaffHandler)
+	adminGroup.Get("/roles", controller.GetAllRolesHandler)
+}
+
+func setupAuditRoutes(app *fiber.App, db *gorm.DB, jwt configs.JWT) {
+	repository := repositories.NewGormAuditRepository(db)
+	usecase := usecases.NewAuditUseCase(repository)
+	controller := controllers.NewAuditController(usecase)
+
+	auditGroup := app.Group("/audit", middlewares.JWTMiddleware(jwt, db), middlewares.RequirePermission("audit:read"))
+	auditGroup.Get("/", controller.GetAuditLogsHandler)
+}
+
+func setupAnalyticsRoutes(app *fiber.App, db *gorm.DB, jwt configs.JWT) {
+	repository := repositories.NewGormAnalyticsRepository(db)
+	usecase := usecases.NewAnalyticsUseCase(repository)
+	controller := controllers.NewAnalyticsController(usecase)
+
+	analyticsGroup := app.Group("/analytics", middlewares.JWTMiddleware(jwt, db), middlewares.RequirePermission("analytics:read"))
+	analyticsGroup.Get("/screening", controller.GetScreeningAnalyticsHandler)
+	analyticsGroup.Get("/screening/export.csv", controller.ExportScreeningAnalyticsHandler)
+}
+
 func setupQuestRoutes(app *fiber.App, db *gorm.DB, jwt configs.JWT) {
 	repository := repositories.NewGormQuestionRepository(db)
 	usecase := usecases.NewQuestionUseCase(repository)
 	controller := controllers.NewQuestionController(usecase)
 
-	questionGroup := app.Group("/question", middlewares.JWTMiddleware(jwt))
-	questionGroup.Post("/", middlewares.AdminMiddleware, controller.CreateQuestionHandler)
+	questionGroup := app.Group("/question", middlewares.JWTMiddleware(jwt, db))
+	questionGroup.Post("/", middlewares.RequirePermission("content:write"), controller.CreateQuestionHandler)
 	questionGroup.Get("/", controller.GetAllQuestionHandler)
 	questionGroup.Get("/:id", controller.GetQuestionByIDHandler)
-	questionGroup.Put("/:id", middlewares.AdminMiddleware, controller.UpdateQuestionByIDHandler)
-	questionGroup.Delete("/:id", controller.DeleteQuestionByIDHandler)
+	questionGroup.Put("/:id", middlewares.RequirePermission("content:write"), controller.UpdateQuestionByIDHandler)
+	questionGroup.Delete("/:id", middlewares.RequirePermission("content:write"), controller.DeleteQuestionByIDHandler)
 }
 
 func setupVideoRoutes(app *fiber.App, db *gorm.DB, jwt configs.JWT, supa configs.Supabase) {
@@ -86,29 +132,42 @@ func setupVideoRoutes(app *fiber.App, db *gorm.DB, jwt configs.JWT, supa configs
 	usecase := usecases.NewVideoUseCase(repository, likerepository, supa)
 	controller := controllers.NewVideoController(usecase)
 
-	videoGroup := app.Group("/video", middlewares.JWTMiddleware(jwt))
-	videoGroup.Post("/", middlewares.AdminMiddleware, controller.CreateVideoHandler)
+	videoGroup := app.Group("/video", middlewares.JWTMiddleware(jwt, db))
+	videoGroup.Post("/", middlewares.RequirePermission("content:write"), controller.CreateVideoHandler)
 	videoGroup.Get("/", controller.GetAllVideoHandler)
 	videoGroup.Get("/:id", controller.GetVideoByIDHandler)
-	videoGroup.Put("/:id", middlewares.AdminMiddleware, controller.UpdateVideoHandler)
-	videoGroup.Delete("/:id", middlewares.AdminMiddleware, controller.DeleteVideoByIDHandler)
+	videoGroup.Put("/:id", middlewares.RequirePermission("content:write"), controller.UpdateVideoHandler)
+	videoGroup.Delete("/:id", middlewares.RequirePermission("content:write"), controller.DeleteVideoByIDHandler)
 }
 
 func setupUserRoutes(app *fiber.App, db *gorm.DB, jwt configs.JWT, supa configs.Supabase, mail configs.Mail, chat configs.Chat) {
 	repository := repositories.NewGormUserRepository(db)
 	kidrepository := repositories.NewGormKidsRepository(db)
-	usecase := usecases.NewUserUseCase(repository, supa, mail, chat)
-	kidusecase := usecases.NewKidUseCase(kidrepository, supa)
+	inviterepository := repositories.NewGormInviteRepository(db)
+	alertrepository := repositories.NewGormGrowthAlertRepository(db)
+	privacyrepository := repositories.NewGormPrivacyRepository(db)
+	usecase := usecases.NewUserUseCase(repository, inviterepository, jwt, supa, mail, chat)
+	kidusecase := usecases.NewKidUseCase(kidrepository, alertrepository, supa)
+	inviteusecase := usecases.NewInviteUseCase(inviterepository, repository, jwt, mail)
+	privacyusecase := usecases.NewPrivacyUseCase(privacyrepository, repository, supa)
 	controller := controllers.NewUserController(usecase, kidusecase)
+	invitecontroller := controllers.NewInviteController(inviteusecase)
+	privacycontroller := controllers.NewPrivacyController(privacyusecase)
 
-	userGroup := app.Group("/user", middlewares.JWTMiddleware(jwt))
-	userGroup.Post("/", middlewares.AdminMiddleware, controller.CreateUserandKidsHandler)
+	userGroup := app.Group("/user", middlewares.JWTMiddleware(jwt, db))
+	userGroup.Post("/", middlewares.RequirePermission("user:write"), controller.CreateUserandKidsHandler)
 	userGroup.Post("/chat", controller.ChatBotHandler)
-	userGroup.Get("/", middlewares.AdminMiddleware, controller.GetAllMomHandler)
+	userGroup.Get("/", middlewares.RequirePermission("user:read"), controller.GetAllMomHandler)
+	userGroup.Get("/me/export", privacycontroller.ExportUserDataHandler)
+	userGroup.Delete("/me", privacycontroller.EraseOwnAccountHandler)
+	userGroup.Get("/invites", middlewares.RequirePermission("user:read"), invitecontroller.GetAllInvitesHandler)
+	userGroup.Get("/:id/invite", middlewares.RequirePermission("user:read"), invitecontroller.GetInviteByUserIDHandler)
+	userGroup.Post("/:id/invite/resend", middlewares.RequirePermission("user:write"), invitecontroller.ResendInviteHandler)
+	userGroup.Delete("/:id/invite", middlewares.RequirePermission("user:write"), invitecontroller.RevokeInviteHandler)
 	userGroup.Get("/info/:id", controller.GetMomByIDHandler)
 	userGroup.Put("/", controller.UpdateUserByIDForUserHandler)
-	userGroup.Put("/:id", controller.UpdateUserByIDForAdminHandler)
-	userGroup.Delete("/:id", middlewares.AdminMiddleware, controller.DeleteUserHandler)
+	userGroup.Put("/:id", middlewares.RequirePermission("user:write"), controller.UpdateUserByIDForAdminHandler)
+	userGroup.Delete("/:id", middlewares.RequirePermission("user:write"), privacycontroller.EraseUserHandler)
 }
 
 func setupLikeRoutes(app *fiber.App, db *gorm.DB, jwt configs.JWT) {
@@ -116,52 +175,111 @@ func setupLikeRoutes(app *fiber.App, db *gorm.DB, jwt configs.JWT) {
 	usecase := usecases.NewLikeUseCase(repository)
 	controller := controllers.NewLikeController(usecase)
 
-	likeGroup := app.Group("/like", middlewares.JWTMiddleware(jwt))
+	likeGroup := app.Group("/like", middlewares.JWTMiddleware(jwt, db))
 	likeGroup.Post("/", controller.CreateLikeHandler)
 	likeGroup.Get("/", controller.GetLikeByUserIDHandler)
 	likeGroup.Get("/:video_id", controller.CheckLikeHandler)
 	likeGroup.Delete("/:video_id", controller.DeleteLikeByIDHandler)
 }
 
-func setupQuizRoutes(app *fiber.App, db *gorm.DB, jwt configs.JWT, supa configs.Supabase) {
+func setupQuizRoutes(app *fiber.App, db *gorm.DB, syncusecase usecases.HistorySyncUseCase, jwt configs.JWT, supa configs.Supabase) {
 	repository := repositories.NewGormQuizRepository(db)
-	usecase := usecases.NewQuizUseCase(repository, supa)
+	categoryrepository := repositories.NewGormCategoryRepository(db)
+	periodrepository := repositories.NewGormPeriodRepository(db)
+	usecase := usecases.NewQuizUseCase(repository, categoryrepository, periodrepository, syncusecase, supa)
 	controller := controllers.NewQuizController(usecase)
+	synccontroller := controllers.NewHistorySyncController(syncusecase)
 
-	quizGroup := app.Group("/quiz", middlewares.JWTMiddleware(jwt))
-	quizGroup.Post("/", middlewares.AdminMiddleware, controller.CreateQuizHandler)
+	quizGroup := app.Group("/quiz", middlewares.JWTMiddleware(jwt, db))
+	quizGroup.Post("/", middlewares.RequirePermission("content:write"), controller.CreateQuizHandler)
 	quizGroup.Get("/", controller.GetAllQuizHandler)
+	quizGroup.Post("/import", middlewares.RequirePermission("content:write"), controller.ImportQuizzesHandler)
+	quizGroup.Get("/export.zip", middlewares.RequirePermission("content:write"), controller.ExportQuizzesHandler)
+	quizGroup.Get("/sync", middlewares.RequirePermission("content:write"), synccontroller.GetLastHistorySyncHandler)
+	quizGroup.Get("/sync/preview", middlewares.RequirePermission("content:write"), synccontroller.PreviewHistorySyncHandler)
+	quizGroup.Post("/sync", middlewares.RequirePermission("content:write"), synccontroller.TriggerHistorySyncHandler)
 	quizGroup.Get("/period/:period/category/:category/question/:id", controller.GetQuizByIDandPeriodHandler)
 	quizGroup.Get("/period/:period/category/:category", controller.GetQuizByCategoryandPeriodHandler)
 	quizGroup.Get("/:id", controller.GetQuizByIDHandler)
-	quizGroup.Put("/:id", middlewares.AdminMiddleware, controller.UpdateQuizByIDHandler)
-	quizGroup.Delete("/:id", middlewares.AdminMiddleware, controller.DeleteQuizByIDHandler)
+	quizGroup.Get("/:id/versions", controller.GetQuizVersionsHandler)
+	quizGroup.Get("/:id/versions/:version", controller.GetQuizVersionHandler)
+	quizGroup.Put("/:id", middlewares.RequirePermission("content:write"), controller.UpdateQuizByIDHandler)
+	quizGroup.Delete("/:id", middlewares.RequirePermission("content:write"), controller.RetireQuizByIDHandler)
 }
 
-func setupKidRoutes(app *fiber.App, db *gorm.DB, jwt configs.JWT, supa configs.Supabase) {
+func setupCategoryRoutes(app *fiber.App, db *gorm.DB, jwt configs.JWT) {
+	repository := repositories.NewGormCategoryRepository(db)
+	usecase := usecases.NewCategoryUseCase(repository)
+	controller := controllers.NewCategoryController(usecase)
+
+	categoryGroup := app.Group("/category", middlewares.JWTMiddleware(jwt, db))
+	categoryGroup.Get("/", controller.GetAllCategoriesHandler)
+	categoryGroup.Post("/", middlewares.RequirePermission("screening:manage"), controller.CreateCategoryHandler)
+	categoryGroup.Put("/order", middlewares.RequirePermission("screening:manage"), controller.ReorderCategoriesHandler)
+	categoryGroup.Put("/:id", middlewares.RequirePermission("screening:manage"), controller.UpdateCategoryHandler)
+	categoryGroup.Put("/:id/rule", middlewares.RequirePermission("screening:manage"), controller.UpdateCategoryRuleHandler)
+	categoryGroup.Delete("/:id", middlewares.RequirePermission("screening:manage"), controller.RetireCategoryHandler)
+}
+
+func setupPeriodRoutes(app *fiber.App, db *gorm.DB, jwt configs.JWT) {
+	repository := repositories.NewGormPeriodRepository(db)
+	usecase := usecases.NewPeriodUseCase(repository)
+	controller := controllers.NewPeriodController(usecase)
+
+	periodGroup := app.Group("/period", middlewares.JWTMiddleware(jwt, db))
+	periodGroup.Get("/", controller.GetAllPeriodsHandler)
+	periodGroup.Post("/", middlewares.RequirePermission("screening:manage"), controller.CreatePeriodHandler)
+	periodGroup.Put("/order", middlewares.RequirePermission("screening:manage"), controller.ReorderPeriodsHandler)
+	periodGroup.Put("/:id", middlewares.RequirePermission("screening:manage"), controller.UpdatePeriodHandler)
+	periodGroup.Delete("/:id", middlewares.RequirePermission("screening:manage"), controller.RetirePeriodHandler)
+}
+
+func setupKidRoutes(app *fiber.App, db *gorm.DB, jwt configs.JWT, supa configs.Supabase, mail configs.Mail, report configs.Report) {
 	repository := repositories.NewGormKidsRepository(db)
-	usecase := usecases.NewKidUseCase(repository, supa)
+	userrepository := repositories.NewGormUserRepository(db)
+	caregiverrepository := repositories.NewGormCaregiverRepository(db)
+	inviterepository := repositories.NewGormInviteRepository(db)
+	alertrepository := repositories.NewGormGrowthAlertRepository(db)
+	growthrepository := repositories.NewGormGrowthRepository(db)
+	historyrepository := repositories.NewGormHistoryRepository(db)
+	evaluaterepository := repositories.NewGormEvaluateRepository(db)
+	apprepository := repositories.NewGormAppRepository(db)
+	usecase := usecases.NewKidUseCase(repository, alertrepository, supa)
+	caregiverusecase := usecases.NewCaregiverUseCase(caregiverrepository, userrepository, repository, inviterepository, jwt, mail)
+	growthusecase := usecases.NewGrowthUseCase(growthrepository, repository, alertrepository)
+	referralrepository := repositories.NewGormReferralRepository(db)
+	historyusecase := usecases.NewHistoryUseCase(historyrepository, evaluaterepository, referralrepository, supa)
+	reportusecase := usecases.NewReportUseCase(usecase, growthusecase, historyusecase, repository, evaluaterepository, apprepository, report)
 	controller := controllers.NewKidController(usecase)
+	caregivercontroller := controllers.NewCaregiverController(caregiverusecase)
+	reportcontroller := controllers.NewReportController(reportusecase)
 
-	kidGroup := app.Group("/kid", middlewares.JWTMiddleware(jwt))
-	kidGroup.Post("/:id", middlewares.AdminMiddleware, controller.CreateKidHandler)
-	kidGroup.Get("/:id", controller.GetKidByIDHandler)
-	kidGroup.Put("/:id", middlewares.AdminMiddleware, controller.UpdateKidByIDHandler)
+	kidGroup := app.Group("/kid", middlewares.JWTMiddleware(jwt, db))
+	kidGroup.Get("/shared", caregivercontroller.GetSharedKidsHandler)
+	kidGroup.Post("/:id", middlewares.RequirePermission("kid:write"), controller.CreateKidHandler)
+	kidGroup.Get("/:id", middlewares.KidOwnerMiddleware(db, "kid:read"), controller.GetKidByIDHandler)
+	kidGroup.Put("/:id", middlewares.RequirePermission("kid:write"), controller.UpdateKidByIDHandler)
+	kidGroup.Get("/:id/report.pdf", middlewares.KidOwnerMiddleware(db, "kid:read"), reportcontroller.GetKidReportHandler)
+	kidGroup.Post("/:id/caregivers", middlewares.KidOwnerMiddleware(db, "kid:write"), caregivercontroller.InviteCaregiverHandler)
+	kidGroup.Get("/:id/caregivers", middlewares.KidOwnerMiddleware(db, "kid:read"), caregivercontroller.GetCaregiversHandler)
+	kidGroup.Put("/:id/caregivers/:caregiverID", middlewares.KidOwnerMiddleware(db, "kid:write"), caregivercontroller.UpdateCaregiverHandler)
+	kidGroup.Delete("/:id/caregivers/:caregiverID", middlewares.KidOwnerMiddleware(db, "kid:write"), caregivercontroller.DeleteCaregiverHandler)
 }
 
 func setupAppointRoutes(app *fiber.App, db *gorm.DB, jwt configs.JWT) {
 	repository := repositories.NewGormAppRepository(db)
-	usecase := usecases.NewAppUseCase(repository)
+	kidrepository := repositories.NewGormKidsRepository(db)
+	usecase := usecases.NewAppUseCase(repository, kidrepository)
 	controller := controllers.NewAppController(usecase)
 
-	appointGroup := app.Group("/appoint", middlewares.JWTMiddleware(jwt))
-	appointGroup.Post("/:userID", middlewares.AdminMiddleware, controller.CreateAppointmentHandler)
+	appointGroup := app.Group("/appoint", middlewares.JWTMiddleware(jwt, db))
+	appointGroup.Post("/:userID", middlewares.RequirePermission("appointment:write"), controller.CreateAppointmentHandler)
 	appointGroup.Get("/", controller.GetAppHandler)
 	appointGroup.Get("/:id", controller.GetAppByIDHandler)
 	appointGroup.Get("/history/progress", controller.GetAppInProgressUserIDHandler)
-	appointGroup.Get("/history/mom/:id", middlewares.AdminMiddleware, controller.GetAllAppUserIDHandler)
-	appointGroup.Put("/:id", middlewares.AdminMiddleware, controller.UpdateAppByIDHandler)
-	appointGroup.Delete("/:id", middlewares.AdminMiddleware, controller.DeleteAppByIDHandler)
+	appointGroup.Get("/history/mom/:id", middlewares.RequirePermission("appointment:read"), controller.GetAllAppUserIDHandler)
+	appointGroup.Put("/:id", middlewares.RequirePermission("appointment:write"), controller.UpdateAppByIDHandler)
+	appointGroup.Delete("/:id", middlewares.RequirePermission("appointment:write"), controller.DeleteAppByIDHandler)
 }
 
 func setupCareRoutes(app *fiber.App, db *gorm.DB, jwt configs.JWT, supa configs.Supabase) {
@@ -169,25 +287,26 @@ func setupCareRoutes(app *fiber.App, db *gorm.DB, jwt configs.JWT, supa configs.
 	usecase := usecases.NewCareUseCase(repository, supa)
 	controller := controllers.NewCareController(usecase)
 
-	careGroup := app.Group("/care", middlewares.JWTMiddleware(jwt))
-	careGroup.Post("/", middlewares.AdminMiddleware, controller.CreateCareHandler)
+	careGroup := app.Group("/care", middlewares.JWTMiddleware(jwt, db))
+	careGroup.Post("/", middlewares.RequirePermission("content:write"), controller.CreateCareHandler)
 	careGroup.Get("/", controller.GetAllCareHandler)
 	careGroup.Get("/:id", controller.GetCareByID)
-	careGroup.Put("/:id", middlewares.AdminMiddleware, controller.UpdateCareHandler)
-	careGroup.Delete("/:id", middlewares.AdminMiddleware, controller.DeleteCareCareHandler)
+	careGroup.Put("/:id", middlewares.RequirePermission("content:write"), controller.UpdateCareHandler)
+	careGroup.Delete("/:id", middlewares.RequirePermission("content:write"), controller.DeleteCareCareHandler)
 }
 
-func setupHistoryRoutes(app *fiber.App, db *gorm.DB, jwt configs.JWT) {
+func setupHistoryRoutes(app *fiber.App, db *gorm.DB, jwt configs.JWT, supa configs.Supabase) {
 	repository := repositories.NewGormHistoryRepository(db)
 	evaluate := repositories.NewGormEvaluateRepository(db)
-	usecase := usecases.NewHistoryUseCase(repository, evaluate)
+	referral := repositories.NewGormReferralRepository(db)
+	usecase := usecases.NewHistoryUseCase(repository, evaluate, referral, supa)
 	controller := controllers.NewHistoryController(usecase)
 
-	historyGroup := app.Group("/history", middlewares.JWTMiddleware(jwt))
-	historyGroup.Post("/evaluate/:times/category/:category/kid/:id", controller.CreateHistoryHandler)
-	historyGroup.Get("/evaluate/:times/kid/:id", controller.GetHistoryHandler)
-	historyGroup.Get("/latest/:times/category/:category/kid/:id", controller.GetLatestHistoryHandler)
-	historyGroup.Get("/result/evaluate/:times/kid/:id", controller.GetHistoryResultHandler)
+	historyGroup := app.Group("/history", middlewares.JWTMiddleware(jwt, db))
+	historyGroup.Post("/evaluate/:times/category/:category/kid/:id", middlewares.KidOwnerMiddleware(db, "evaluate:write"), controller.CreateHistoryHandler)
+	historyGroup.Get("/evaluate/:times/kid/:id", middlewares.KidOwnerMiddleware(db, "kid:read"), controller.GetHistoryHandler)
+	historyGroup.Get("/latest/:times/category/:category/kid/:id", middlewares.KidOwnerMiddleware(db, "kid:read"), controller.GetLatestHistoryHandler)
+	historyGroup.Get("/result/evaluate/:times/kid/:id", middlewares.KidOwnerMiddleware(db, "kid:read"), controller.GetHistoryResultHandler)
 }
 
 func setupEvaluateRoutes(app *fiber.App, db *gorm.DB, jwt configs.JWT) {
@@ -195,19 +314,41 @@ func setupEvaluateRoutes(app *fiber.App, db *gorm.DB, jwt configs.JWT) {
 	usecase := usecases.NewEvaluateUseCase(repository)
 	controller := controllers.NewEvaluateController(usecase)
 
-	evaluateGroup := app.Group("/evaluate", middlewares.JWTMiddleware(jwt))
-	evaluateGroup.Get("/all/:id", controller.GetAllEvaluateHandler)
+	evaluateGroup := app.Group("/evaluate", middlewares.JWTMiddleware(jwt, db))
+	evaluateGroup.Get("/all/:id", middlewares.KidOwnerMiddleware(db, "kid:read"), controller.GetAllEvaluateHandler)
+	evaluateGroup.Get("/overdue", middlewares.RequirePermission("kid:read"), controller.GetOverdueKidsHandler)
+}
+
+func setupReferralRoutes(app *fiber.App, db *gorm.DB, jwt configs.JWT) {
+	repository := repositories.NewGormReferralRepository(db)
+	userrepository := repositories.NewGormUserRepository(db)
+	kidrepository := repositories.NewGormKidsRepository(db)
+	apprepository := repositories.NewGormAppRepository(db)
+	usecase := usecases.NewReferralUseCase(repository, userrepository, kidrepository, apprepository)
+	controller := controllers.NewReferralController(usecase)
+
+	referralGroup := app.Group("/referral", middlewares.JWTMiddleware(jwt, db), middlewares.RequirePermission("referral:manage"))
+	referralGroup.Get("/", controller.GetReferralsHandler)
+	referralGroup.Get("/:id", controller.GetReferralByIDHandler)
+	referralGroup.Put("/:id", controller.UpdateReferralHandler)
 }
 
 func setupGrowthRoutes(app *fiber.App, db *gorm.DB, jwt configs.JWT) {
 	repository := repositories.NewGormGrowthRepository(db)
 	kidrepository := repositories.NewGormKidsRepository(db)
-	usecase := usecases.NewGrowthUseCase(repository, kidrepository)
+	alertrepository := repositories.NewGormGrowthAlertRepository(db)
+	usecase := usecases.NewGrowthUseCase(repository, kidrepository, alertrepository)
 	controller := controllers.NewGrowthController(usecase)
 
-	growthGroup := app.Group("/growth", middlewares.JWTMiddleware(jwt))
-	growthGroup.Post("/kid/:id", controller.CreateGrowthHandler)
-	growthGroup.Get("/kid/:id/summary", controller.GetSummary)
-	growthGroup.Get("/kid/:id/all", controller.GetAllGrowth)
-	growthGroup.Put("/:id", controller.UpdateGrowthByID)
+	growthGroup := app.Group("/growth", middlewares.JWTMiddleware(jwt, db))
+	growthGroup.Get("/alerts", middlewares.RequirePermission("kid:read"), controller.GetAlertsHandler)
+	growthGroup.Post("/import", middlewares.RequirePermission("growth:write"), controller.ImportGrowthHandler)
+	growthGroup.Get("/export.csv", middlewares.RequirePermission("growth:export"), controller.ExportAllGrowthHandler)
+	growthGroup.Post("/kid/:id", middlewares.KidOwnerMiddleware(db, "growth:write"), controller.CreateGrowthHandler)
+	growthGroup.Get("/kid/:id/summary", middlewares.KidOwnerMiddleware(db, "kid:read"), controller.GetSummary)
+	growthGroup.Get("/kid/:id/all", middlewares.KidOwnerMiddleware(db, "kid:read"), controller.GetAllGrowth)
+	growthGroup.Get("/kid/:id/chart.svg", middlewares.KidOwnerMiddleware(db, "kid:read"), controller.GetChartSVGHandler)
+	growthGroup.Get("/kid/:id/chart.png", middlewares.KidOwnerMiddleware(db, "kid:read"), controller.GetChartPNGHandler)
+	growthGroup.Get("/kid/:id/export.csv", middlewares.KidOwnerMiddleware(db, "kid:read"), controller.ExportGrowthHandler)
+	growthGroup.Put("/:id", middlewares.GrowthOwnerMiddleware(db, "growth:write"), controller.UpdateGrowthByID)
 }
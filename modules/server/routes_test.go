@@ -0,0 +1,315 @@
+package server
+
+import (
+	"Beside-Mom-BE/configs"
+	"Beside-Mom-BE/modules/repositories"
+	"Beside-Mom-BE/modules/usecases"
+	"Beside-Mom-BE/pkg/database"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/recover"
+	"github.com/golang-jwt/jwt/v5"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const (
+	testSecret    = "route-test-secret"
+	ownKid        = "kid-own"
+	foreignKid    = "kid-foreign"
+	ownGrowth     = "growth-own"
+	foreignGrowth = "growth-foreign"
+)
+
+var errUnexpectedQuery = errors.New("route test: query not served by the fake database")
+
+var routeKids = map[string]string{
+	ownKid:     "mom",
+	foreignKid: "other-mom",
+}
+
+var routeGrowths = map[string]string{
+	ownGrowth:     ownKid,
+	foreignGrowth: foreignKid,
+}
+
+var routeCaregivers = []struct {
+	kidID   string
+	userID  string
+	access  string
+	revoked bool
+}{
+	{ownKid, "viewer", "view", false},
+	{ownKid, "recorder", "record_growth", false},
+	{ownKid, "revoked", "record_growth", true},
+}
+
+var routePermissions = map[string][]string{
+	"Admin": {"user:read", "user:write", "kid:read", "kid:write", "growth:write", "evaluate:write"},
+	"Nurse": {"user:read", "kid:read", "growth:write", "evaluate:write"},
+}
+
+type routeDriver struct{}
+
+type routeConn struct{}
+
+type routeRows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+var (
+	placeholder = regexp.MustCompile(`\$\d+`)
+	registerDB  sync.Once
+)
+
+func (routeDriver) Open(string) (driver.Conn, error) {
+	return routeConn{}, nil
+}
+
+func (routeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errUnexpectedQuery
+}
+
+func (routeConn) Close() error {
+	return nil
+}
+
+func (routeConn) Begin() (driver.Tx, error) {
+	return nil, errUnexpectedQuery
+}
+
+func (routeConn) ExecContext(context.Context, string, []driver.NamedValue) (driver.Result, error) {
+	return nil, errUnexpectedQuery
+}
+
+func (routeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	arg := func(i int) string {
+		if i >= len(args) {
+			return ""
+		}
+		value, _ := args[i].Value.(string)
+		return value
+	}
+
+	query = placeholder.ReplaceAllString(query, "?")
+	switch {
+	case strings.Contains(query, `FROM "sessions"`):
+		return &routeRows{
+			columns: []string{"id", "user_id", "expires_at", "revoked_at"},
+			values:  [][]driver.Value{{arg(0), strings.TrimPrefix(arg(0), "session-"), time.Now().Add(time.Hour), nil}},
+		}, nil
+	case strings.Contains(query, `FROM "permissions"`):
+		rows := &routeRows{columns: []string{"name"}}
+		for _, permission := range routePermissions[arg(0)] {
+			rows.values = append(rows.values, []driver.Value{permission})
+		}
+		return rows, nil
+	case strings.Contains(query, `FROM "kids"`) && strings.Contains(query, "WHERE id = ?"):
+		rows := &routeRows{columns: []string{"id", "user_id"}}
+		if owner, ok := routeKids[arg(0)]; ok {
+			rows.values = append(rows.values, []driver.Value{arg(0), owner})
+		}
+		return rows, nil
+	case strings.Contains(query, `FROM "kid_caregivers"`) && strings.Contains(query, "revoked_at IS NULL"):
+		rows := &routeRows{columns: []string{"id", "kid_id", "user_id", "access"}}
+		for _, caregiver := range routeCaregivers {
+			if caregiver.kidID == arg(0) && caregiver.userID == arg(1) && !caregiver.revoked {
+				rows.values = append(rows.values, []driver.Value{"caregiver-" + caregiver.userID, caregiver.kidID, caregiver.userID, caregiver.access})
+			}
+		}
+		return rows, nil
+	case strings.Contains(query, `FROM "growths"`) && strings.Contains(query, "WHERE id = ?"):
+		rows := &routeRows{columns: []string{"id", "kid_id"}}
+		if kidID, ok := routeGrowths[arg(0)]; ok {
+			rows.values = append(rows.values, []driver.Value{arg(0), kidID})
+		}
+		return rows, nil
+	}
+
+	return nil, errUnexpectedQuery
+}
+
+func (r *routeRows) Columns() []string {
+	return r.columns
+}
+
+func (r *routeRows) Close() error {
+	return nil
+}
+
+func (r *routeRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+func newRouteTestApp(t *testing.T) *fiber.App {
+	t.Helper()
+	registerDB.Do(func() {
+		sql.Register("routetest", routeDriver{})
+	})
+
+	conn, err := sql.Open("routetest", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: conn}), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	database.SetDB(db)
+
+	app := fiber.New()
+	app.Use(recover.New())
+	historySync := usecases.NewHistorySyncUseCase(repositories.NewGormHistoryRepository(db))
+	SetupRoutes(app, historySync, configs.JWT{Secret: testSecret}, configs.Supabase{}, configs.Mail{}, configs.Chat{}, configs.Report{})
+	return app
+}
+
+func routeToken(t *testing.T, userID string, role string) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id":    userID,
+		"role":       role,
+		"session_id": "session-" + userID,
+		"exp":        time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte(testSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return "Bearer " + token
+}
+
+func routeStatus(t *testing.T, app *fiber.App, method string, path string, userID string, role string) int {
+	t.Helper()
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set("Authorization", routeToken(t, userID, role))
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode
+}
+
+func kidScopedPath(route string, kidID string, growthID string) string {
+	segments := strings.Split(route, "/")
+	for i, segment := range segments {
+		switch {
+		case segment == ":id" && strings.HasPrefix(route, "/growth/:id"):
+			segments[i] = growthID
+		case segment == ":id":
+			segments[i] = kidID
+		case strings.HasPrefix(segment, ":"):
+			segments[i] = "1"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+func TestKidRoutesRefuseForeignKids(t *testing.T) {
+	app := newRouteTestApp(t)
+	checked := 0
+	for _, route := range app.GetRoutes(true) {
+		if route.Method == http.MethodHead || !strings.Contains(route.Path, "/:id") {
+			continue
+		}
+
+		scoped := false
+		for _, prefix := range []string{"/kid/", "/growth/", "/history/", "/evaluate/"} {
+			scoped = scoped || strings.HasPrefix(route.Path, prefix)
+		}
+		if !scoped {
+			continue
+		}
+
+		checked++
+		path := kidScopedPath(route.Path, foreignKid, foreignGrowth)
+		if status := routeStatus(t, app, route.Method, path, "mom", "User"); status != fiber.StatusForbidden {
+			t.Errorf("%s %s as another kid's mom: got %d, want %d", route.Method, route.Path, status, fiber.StatusForbidden)
+		}
+	}
+
+	if checked == 0 {
+		t.Fatal("no kid-scoped routes were registered")
+	}
+}
+
+func TestKidRoutesAdmitOwnersStaffAndCaregivers(t *testing.T) {
+	app := newRouteTestApp(t)
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		user   string
+		role   string
+		allow  bool
+	}{
+		{"owner reads kid", http.MethodGet, "/kid/" + ownKid, "mom", "User", true},
+		{"owner records growth", http.MethodPost, "/growth/kid/" + ownKid, "mom", "User", true},
+		{"owner updates growth", http.MethodPut, "/growth/" + ownGrowth, "mom", "User", true},
+		{"owner reads history", http.MethodGet, "/history/evaluate/1/kid/" + ownKid, "mom", "User", true},
+		{"owner reads evaluations", http.MethodGet, "/evaluate/all/" + ownKid, "mom", "User", true},
+		{"admin reads foreign kid", http.MethodGet, "/kid/" + foreignKid, "admin", "Admin", true},
+		{"nurse records foreign growth", http.MethodPost, "/growth/kid/" + foreignKid, "nurse", "Nurse", true},
+		{"viewer reads kid", http.MethodGet, "/kid/" + ownKid, "viewer", "User", true},
+		{"viewer reads growth", http.MethodGet, "/growth/kid/" + ownKid + "/all", "viewer", "User", true},
+		{"viewer reads history", http.MethodGet, "/history/evaluate/1/kid/" + ownKid, "viewer", "User", true},
+		{"viewer reads evaluations", http.MethodGet, "/evaluate/all/" + ownKid, "viewer", "User", true},
+		{"viewer records growth", http.MethodPost, "/growth/kid/" + ownKid, "viewer", "User", false},
+		{"viewer updates growth", http.MethodPut, "/growth/" + ownGrowth, "viewer", "User", false},
+		{"viewer submits history", http.MethodPost, "/history/evaluate/1/category/1/kid/" + ownKid, "viewer", "User", false},
+		{"viewer invites caregiver", http.MethodPost, "/kid/" + ownKid + "/caregivers", "viewer", "User", false},
+		{"viewer reads foreign kid", http.MethodGet, "/kid/" + foreignKid, "viewer", "User", false},
+		{"recorder records growth", http.MethodPost, "/growth/kid/" + ownKid, "recorder", "User", true},
+		{"recorder updates growth", http.MethodPut, "/growth/" + ownGrowth, "recorder", "User", true},
+		{"recorder submits history", http.MethodPost, "/history/evaluate/1/category/1/kid/" + ownKid, "recorder", "User", false},
+		{"revoked caregiver reads kid", http.MethodGet, "/kid/" + ownKid, "revoked", "User", false},
+		{"revoked caregiver records growth", http.MethodPost, "/growth/kid/" + ownKid, "revoked", "User", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := routeStatus(t, app, tt.method, tt.path, tt.user, tt.role)
+			if tt.allow && (status == fiber.StatusUnauthorized || status == fiber.StatusForbidden) {
+				t.Errorf("%s %s as %s: got %d, want the request to pass the guard", tt.method, tt.path, tt.user, status)
+			}
+			if !tt.allow && status != fiber.StatusForbidden {
+				t.Errorf("%s %s as %s: got %d, want %d", tt.method, tt.path, tt.user, status, fiber.StatusForbidden)
+			}
+		})
+	}
+}
+
+func TestKidRoutesReportUnknownKids(t *testing.T) {
+	app := newRouteTestApp(t)
+	if status := routeStatus(t, app, http.MethodGet, "/kid/kid-missing", "mom", "User"); status != fiber.StatusNotFound {
+		t.Errorf("unknown kid: got %d, want %d", status, fiber.StatusNotFound)
+	}
+
+	if status := routeStatus(t, app, http.MethodPut, "/growth/growth-missing", "mom", "User"); status != fiber.StatusNotFound {
+		t.Errorf("unknown growth: got %d, want %d", status, fiber.StatusNotFound)
+	}
+}
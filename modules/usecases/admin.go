@@ -0,0 +1,176 @@
+package usecases
+
+import (
+	"Beside-Mom-BE/configs"
+	"Beside-Mom-BE/modules/entities"
+	"Beside-Mom-BE/modules/repositories"
+	"Beside-Mom-BE/pkg/utils"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type AdminUseCase interface {
+	CreateAdmin(user *entities.User) (*entities.User, error)
+	InviteStaff(user *entities.User, roleName string) (*entities.User, error)
+	GetAllStaff() ([]entities.User, error)
+	GetAllRoles() ([]entities.Role, error)
+	DisableAdmin(email string) error
+	ResetAdminPassword(email, password string) error
+}
+
+type AdminUseCaseImpl struct {
+	repo        repositories.UserRepository
+	sessionRepo repositories.SessionRepository
+	inviteRepo  repositories.InviteRepository
+	roleRepo    repositories.RoleRepository
+	jwt         configs.JWT
+	mail        configs.Mail
+}
+
+func NewAdminUseCase(repo repositories.UserRepository, sessionRepo repositories.SessionRepository, inviteRepo repositories.InviteRepository, roleRepo repositories.RoleRepository, jwt configs.JWT, mail configs.Mail) *AdminUseCaseImpl {
+	return &AdminUseCaseImpl{
+		repo:        repo,
+		sessionRepo: sessionRepo,
+		roleRepo:    roleRepo,
+		inviteRepo:  inviteRepo,
+		jwt:         jwt,
+		mail:        mail,
+	}
+}
+
+func (u *AdminUseCaseImpl) CreateAdmin(user *entities.User) (*entities.User, error) {
+	if user.Password == "" {
+		return nil, errors.New("password is required")
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Password = string(hashedPassword)
+	user.Status = "active"
+	return u.insertStaff(user, "Admin")
+}
+
+func (u *AdminUseCaseImpl) InviteStaff(user *entities.User, roleName string) (*entities.User, error) {
+	if roleName == "User" {
+		return nil, errors.New("moms must be created through the user endpoint")
+	}
+
+	user.Password = ""
+	user.Status = "pending"
+	createdUser, err := u.insertStaff(user, roleName)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := issueInvite(u.inviteRepo, createdUser, u.jwt, u.mail); err != nil {
+		return nil, err
+	}
+
+	return createdUser, nil
+}
+
+func (u *AdminUseCaseImpl) insertStaff(user *entities.User, roleName string) (*entities.User, error) {
+	normalizedEmail, err := utils.NormalizeEmail(user.Email)
+	if err != nil {
+		return nil, errors.New("invalid email format")
+	}
+
+	user.Email = normalizedEmail
+	if _, err := u.repo.FindUserByEmail(user.Email); err == nil {
+		return nil, errors.New("this email already have account")
+	}
+
+	role, err := u.repo.GetRoleByName(roleName)
+	if err != nil {
+		return nil, errors.New("role not found")
+	}
+
+	user.ID = uuid.New().String()
+	user.RoleID = role.ID
+	createdUser, err := u.repo.CreateUser(user)
+	if err != nil {
+		return nil, err
+	}
+
+	return createdUser, nil
+}
+
+func (u *AdminUseCaseImpl) GetAllStaff() ([]entities.User, error) {
+	return u.repo.GetAllStaff()
+}
+
+func (u *AdminUseCaseImpl) GetAllRoles() ([]entities.Role, error) {
+	return u.roleRepo.GetAllRoles()
+}
+
+func (u *AdminUseCaseImpl) DisableAdmin(email string) error {
+	admin, err := u.findAdminByEmail(email)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	admin.DisabledAt = &now
+	if _, err := u.repo.UpdateUserByID(admin); err != nil {
+		return err
+	}
+
+	if _, err := u.sessionRepo.RevokeAllSessionsByUserID(admin.ID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (u *AdminUseCaseImpl) ResetAdminPassword(email, password string) error {
+	if password == "" {
+		return errors.New("password is required")
+	}
+
+	admin, err := u.findAdminByEmail(email)
+	if err != nil {
+		return err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	admin.Password = string(hashedPassword)
+	admin.Status = "active"
+	admin.DisabledAt = nil
+	if _, err := u.repo.UpdateUserByID(admin); err != nil {
+		return err
+	}
+
+	if _, err := u.sessionRepo.RevokeAllSessionsByUserID(admin.ID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (u *AdminUseCaseImpl) findAdminByEmail(email string) (*entities.User, error) {
+	normalizedEmail, err := utils.NormalizeEmail(email)
+	if err != nil {
+		return nil, errors.New("invalid email format")
+	}
+
+	user, err := u.repo.FindUserByEmail(normalizedEmail)
+	if err != nil {
+		return nil, errors.New("admin not found")
+	}
+
+	if user.Role.RoleName == "User" {
+		return nil, errors.New("user is not a staff member")
+	}
+
+	return &user, nil
+}
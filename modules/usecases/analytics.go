@@ -0,0 +1,129 @@
+package usecases
+
+import (
+	"Beside-Mom-BE/modules/entities"
+	"Beside-Mom-BE/modules/repositories"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"math"
+	"strconv"
+)
+
+type AnalyticsUseCase interface {
+	GetScreeningAnalytics(filter entities.AnalyticsFilter, limit int) (*entities.ScreeningAnalytics, error)
+	ExportScreeningCSV(filter entities.AnalyticsFilter, section string, limit int) ([]byte, error)
+}
+
+type AnalyticsUseCaseImpl struct {
+	repo repositories.AnalyticsRepository
+}
+
+func NewAnalyticsUseCase(repo repositories.AnalyticsRepository) *AnalyticsUseCaseImpl {
+	return &AnalyticsUseCaseImpl{repo: repo}
+}
+
+func (u *AnalyticsUseCaseImpl) GetScreeningAnalytics(filter entities.AnalyticsFilter, limit int) (*entities.ScreeningAnalytics, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+
+	rates, err := u.repo.GetScreeningRates(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	mostFailed, err := u.repo.GetMostFailedQuizzes(filter, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	attempts, err := u.repo.GetAttemptsToPass(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	cohorts, err := u.repo.GetCohortTrends(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range rates {
+		rates[i].PassRate = percentage(rates[i].Passed, rates[i].Total)
+		rates[i].FailRate = percentage(rates[i].Failed, rates[i].Total)
+		rates[i].PendingRate = percentage(rates[i].Pending, rates[i].Total)
+	}
+
+	for i := range mostFailed {
+		mostFailed[i].FailRate = percentage(mostFailed[i].Failures, mostFailed[i].Attempts)
+	}
+
+	for i := range cohorts {
+		cohorts[i].PassRate = percentage(cohorts[i].Passed, cohorts[i].Total)
+	}
+
+	return &entities.ScreeningAnalytics{
+		From:           filter.From,
+		To:             filter.To,
+		Rates:          rates,
+		MostFailed:     mostFailed,
+		AttemptsToPass: attempts,
+		Cohorts:        cohorts,
+	}, nil
+}
+
+func (u *AnalyticsUseCaseImpl) ExportScreeningCSV(filter entities.AnalyticsFilter, section string, limit int) ([]byte, error) {
+	analytics, err := u.GetScreeningAnalytics(filter, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows [][]string
+	switch section {
+	case "rates":
+		rows = append(rows, []string{"period_id", "period", "category_id", "category", "total", "passed", "failed", "pending", "pass_rate", "fail_rate", "pending_rate"})
+		for _, r := range analytics.Rates {
+			rows = append(rows, []string{strconv.Itoa(r.PeriodID), r.Period, strconv.Itoa(r.CategoryID), r.Category, strconv.Itoa(r.Total), strconv.Itoa(r.Passed),
+				strconv.Itoa(r.Failed), strconv.Itoa(r.Pending), formatRate(r.PassRate), formatRate(r.FailRate), formatRate(r.PendingRate)})
+		}
+	case "failed_quizzes":
+		rows = append(rows, []string{"quiz_id", "question", "period_id", "period", "category_id", "category", "attempts", "failures", "fail_rate"})
+		for _, q := range analytics.MostFailed {
+			rows = append(rows, []string{strconv.Itoa(q.QuizID), q.Question, strconv.Itoa(q.PeriodID), q.Period, strconv.Itoa(q.CategoryID), q.Category,
+				strconv.Itoa(q.Attempts), strconv.Itoa(q.Failures), formatRate(q.FailRate)})
+		}
+	case "attempts":
+		rows = append(rows, []string{"period_id", "period", "category_id", "category", "passed", "average_times"})
+		for _, a := range analytics.AttemptsToPass {
+			rows = append(rows, []string{strconv.Itoa(a.PeriodID), a.Period, strconv.Itoa(a.CategoryID), a.Category, strconv.Itoa(a.Passed), formatRate(a.AverageTimes)})
+		}
+	case "cohorts":
+		rows = append(rows, []string{"cohort", "kids", "total", "passed", "failed", "pending", "pass_rate"})
+		for _, c := range analytics.Cohorts {
+			rows = append(rows, []string{c.Cohort, strconv.Itoa(c.Kids), strconv.Itoa(c.Total), strconv.Itoa(c.Passed), strconv.Itoa(c.Failed),
+				strconv.Itoa(c.Pending), formatRate(c.PassRate)})
+		}
+	default:
+		return nil, errors.New("section must be rates, failed_quizzes, attempts or cohorts")
+	}
+
+	buf := new(bytes.Buffer)
+	w := csv.NewWriter(buf)
+	if err := w.WriteAll(rows); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func percentage(count int, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+
+	return math.Round(float64(count)/float64(total)*10000) / 100
+}
+
+func formatRate(value float64) string {
+	return strconv.FormatFloat(value, 'f', 2, 64)
+}
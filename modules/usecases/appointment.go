@@ -3,28 +3,37 @@ package usecases
 import (
 	"Beside-Mom-BE/modules/entities"
 	"Beside-Mom-BE/modules/repositories"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
 )
 
 type AppUseCase interface {
-	CreateAppointment(app *entities.Appointment) (*entities.Appointment, error)
+	CreateAppointment(app *entities.Appointment, ctx *fiber.Ctx) (*entities.Appointment, error)
 	GetAppByID(id string) (map[string]interface{}, error)
 	GetAppByUserID(userID string) ([]map[string]interface{}, error)
+	GetAppByUserIDWithShared(userID string) ([]map[string]interface{}, error)
 	GetAppInProgressByUserID(userID string) ([]map[string]interface{}, error)
 	GetAllApp() ([]map[string]interface{}, error)
-	UpdateAppByID(id string, app *entities.Appointment) (*entities.Appointment, error)
-	DeleteAppByID(id string) error
+	UpdateAppByID(id string, app *entities.Appointment, ctx *fiber.Ctx) (*entities.Appointment, error)
+	DeleteAppByID(id string, ctx *fiber.Ctx) error
 }
 
 type AppUseCaseImpl struct {
-	repo repositories.AppRepository
+	repo    repositories.AppRepository
+	kidRepo repositories.KidsRepository
 }
 
-func NewAppUseCase(repo repositories.AppRepository) *AppUseCaseImpl {
-	return &AppUseCaseImpl{repo: repo}
+func NewAppUseCase(repo repositories.AppRepository, kidRepo repositories.KidsRepository) *AppUseCaseImpl {
+	return &AppUseCaseImpl{repo: repo, kidRepo: kidRepo}
 }
 
-func (u *AppUseCaseImpl) CreateAppointment(app *entities.Appointment) (*entities.Appointment, error) {
-	createdApp, err := u.repo.CreateAppointment(app)
+func (u *AppUseCaseImpl) CreateAppointment(app *entities.Appointment, ctx *fiber.Ctx) (*entities.Appointment, error) {
+	if err := u.validateAppKid(app.KidID, app.UserID); err != nil {
+		return nil, err
+	}
+
+	createdApp, err := u.repo.WithContext(ctx.UserContext()).CreateAppointment(app)
 	if err != nil {
 		return nil, err
 	}
@@ -48,6 +57,7 @@ func (u *AppUseCaseImpl) GetAppByID(id string) (map[string]interface{}, error) {
 		"requirement": app.Requirement,
 		"status":      app.Status,
 		"user_id":     app.User.ID,
+		"kid_id":      app.KidID,
 		"name":        app.User.Firstname + " " + app.User.Lastname,
 	}
 
@@ -71,6 +81,7 @@ func (u *AppUseCaseImpl) GetAppInProgressByUserID(userID string) ([]map[string]i
 			"doctor":      app.Doctor,
 			"status":      app.Status,
 			"user_id":     app.User.ID,
+			"kid_id":      app.KidID,
 			"name":        app.User.Firstname + " " + app.User.Lastname,
 		}
 
@@ -98,6 +109,35 @@ func (u *AppUseCaseImpl) GetAppByUserID(userID string) ([]map[string]interface{}
 			"doctor":      app.Doctor,
 			"status":      app.Status,
 			"user_id":     app.User.ID,
+			"kid_id":      app.KidID,
+			"name":        app.User.Firstname + " " + app.User.Lastname,
+		}
+
+		appsList = append(appsList, appData)
+	}
+
+	return appsList, nil
+}
+
+func (u *AppUseCaseImpl) GetAppByUserIDWithShared(userID string) ([]map[string]interface{}, error) {
+	apps, err := u.repo.GetAppByUserIDWithShared(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var appsList []map[string]interface{}
+	for _, app := range apps {
+		appData := map[string]interface{}{
+			"id":          app.ID,
+			"title":       app.Title,
+			"date":        app.Date,
+			"start_time":  app.StartTime,
+			"building":    app.Building,
+			"requirement": app.Requirement,
+			"doctor":      app.Doctor,
+			"status":      app.Status,
+			"user_id":     app.User.ID,
+			"kid_id":      app.KidID,
 			"name":        app.User.Firstname + " " + app.User.Lastname,
 		}
 
@@ -125,6 +165,7 @@ func (u *AppUseCaseImpl) GetAllApp() ([]map[string]interface{}, error) {
 			"doctor":      app.Doctor,
 			"status":      app.Status,
 			"user_id":     app.User.ID,
+			"kid_id":      app.KidID,
 			"name":        app.User.Firstname + " " + app.User.Lastname,
 		}
 
@@ -134,12 +175,17 @@ func (u *AppUseCaseImpl) GetAllApp() ([]map[string]interface{}, error) {
 	return appsList, nil
 }
 
-func (u *AppUseCaseImpl) UpdateAppByID(id string, app *entities.Appointment) (*entities.Appointment, error) {
+func (u *AppUseCaseImpl) UpdateAppByID(id string, app *entities.Appointment, ctx *fiber.Ctx) (*entities.Appointment, error) {
 	existingApp, err := u.repo.GetAppByID(id)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := u.validateAppKid(app.KidID, existingApp.UserID); err != nil {
+		return nil, err
+	}
+
+	existingApp.KidID = app.KidID
 	existingApp.Title = app.Title
 	existingApp.Date = app.Date
 	existingApp.StartTime = app.StartTime
@@ -147,9 +193,26 @@ func (u *AppUseCaseImpl) UpdateAppByID(id string, app *entities.Appointment) (*e
 	existingApp.Requirement = app.Requirement
 	existingApp.Doctor = app.Doctor
 	existingApp.Status = app.Status
-	return u.repo.UpdateAppByID(existingApp)
+	return u.repo.WithContext(ctx.UserContext()).UpdateAppByID(existingApp)
+}
+
+func (u *AppUseCaseImpl) DeleteAppByID(id string, ctx *fiber.Ctx) error {
+	return u.repo.WithContext(ctx.UserContext()).DeleteAppByID(id)
 }
 
-func (u *AppUseCaseImpl) DeleteAppByID(id string) error {
-	return u.repo.DeleteAppByID(id)
+func (u *AppUseCaseImpl) validateAppKid(kidID *string, userID string) error {
+	if kidID == nil {
+		return nil
+	}
+
+	ownerID, err := u.kidRepo.GetKidOwnerID(*kidID)
+	if err != nil {
+		return errors.New("kid not found")
+	}
+
+	if ownerID != userID {
+		return errors.New("kid does not belong to this user")
+	}
+
+	return nil
 }
@@ -0,0 +1,40 @@
+package usecases
+
+import (
+	"Beside-Mom-BE/modules/entities"
+	"Beside-Mom-BE/modules/repositories"
+)
+
+type AuditUseCase interface {
+	GetAuditLogs(filter entities.AuditFilter, page int, limit int) (map[string]interface{}, error)
+}
+
+type AuditUseCaseImpl struct {
+	repo repositories.AuditRepository
+}
+
+func NewAuditUseCase(repo repositories.AuditRepository) *AuditUseCaseImpl {
+	return &AuditUseCaseImpl{repo: repo}
+}
+
+func (u *AuditUseCaseImpl) GetAuditLogs(filter entities.AuditFilter, page int, limit int) (map[string]interface{}, error) {
+	if page < 1 {
+		page = 1
+	}
+
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	logs, total, err := u.repo.GetAuditLogs(filter, page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"logs":  logs,
+		"page":  page,
+		"limit": limit,
+		"total": total,
+	}, nil
+}
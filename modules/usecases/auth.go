@@ -5,95 +5,168 @@ import (
 	"Beside-Mom-BE/modules/entities"
 	"Beside-Mom-BE/modules/repositories"
 	"Beside-Mom-BE/pkg/utils"
+	"crypto/subtle"
 	"errors"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 )
 
 type AuthUseCase interface {
-	RegisterAdmin(user *entities.User) (*entities.User, error)
-	Login(email, password string) (string, *entities.User, error)
+	Login(email, password, userAgent, ip string) (string, string, *entities.User, error)
+	RefreshToken(refreshToken, userAgent, ip string) (string, string, error)
+	Logout(sessionID string) error
+	RevokeAllSessions(userID string) (int64, error)
 	ForgotPassword(email string) error
-	VerifyOTP(email, otpCode string) error
-	ChangedPassword(email, newPassword string) error
+	VerifyOTP(email, otpCode string) (string, error)
+	ChangedPassword(email, resetToken, newPassword string) error
 }
 
+const (
+	otpExpire        = 5 * time.Minute
+	otpMaxAttempts   = 5
+	otpLockDuration  = 15 * time.Minute
+	otpResendWait    = time.Minute
+	resetTokenExpire = 10 * time.Minute
+)
+
 type AuthUseCaseImpl struct {
-	repo      repositories.UserRepository
-	jwtSecret string
-	mail      configs.Mail
+	repo        repositories.UserRepository
+	sessionRepo repositories.SessionRepository
+	jwt         configs.JWT
+	mail        configs.Mail
 }
 
-func NewAuthUseCase(repo repositories.UserRepository, jwt configs.JWT, mail configs.Mail) *AuthUseCaseImpl {
+func NewAuthUseCase(repo repositories.UserRepository, sessionRepo repositories.SessionRepository, jwt configs.JWT, mail configs.Mail) *AuthUseCaseImpl {
 	return &AuthUseCaseImpl{
-		repo:      repo,
-		jwtSecret: jwt.Secret,
-		mail:      mail,
+		repo:        repo,
+		sessionRepo: sessionRepo,
+		jwt:         jwt,
+		mail:        mail,
 	}
 }
 
-func (u *AuthUseCaseImpl) RegisterAdmin(user *entities.User) (*entities.User, error) {
-	normalizedEmail, err := utils.NormalizeEmail(user.Email)
+func (u *AuthUseCaseImpl) Login(email, password, userAgent, ip string) (string, string, *entities.User, error) {
+	normalizedEmail, err := utils.NormalizeEmail(email)
 	if err != nil {
-		return nil, errors.New("invalid email format")
+		return "", "", nil, errors.New("invalid email format")
 	}
 
-	user.Email = normalizedEmail
-	if _, err := u.repo.FindUserByEmail(user.Email); err == nil {
-		return nil, errors.New("this email already have account")
+	email = normalizedEmail
+	user, err := u.repo.FindUserByEmail(email)
+	if err != nil {
+		return "", "", nil, errors.New("invalid email")
+	}
+
+	if user.Status == "pending" {
+		return "", "", nil, errors.New("account is not activated, please accept your invite")
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+		return "", "", nil, errors.New("invalid password")
 	}
 
-	role, err := u.repo.GetRoleByName("Admin")
+	if user.DisabledAt != nil {
+		return "", "", nil, errors.New("account is disabled")
+	}
+
+	accessToken, refreshToken, err := u.createSession(&user, userAgent, ip)
 	if err != nil {
-		return nil, errors.New("role not found")
+		return "", "", nil, err
 	}
 
-	user.ID = uuid.New().String()
-	user.RoleID = role.ID
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
+	return accessToken, refreshToken, &user, nil
+}
+
+func (u *AuthUseCaseImpl) RefreshToken(refreshToken, userAgent, ip string) (string, string, error) {
+	session, err := u.sessionRepo.GetSessionByRefreshToken(utils.HashToken(refreshToken))
 	if err != nil {
-		return nil, err
+		return "", "", errors.New("invalid refresh token")
 	}
 
-	user.Password = string(hashedPassword)
-	createdUser, err := u.repo.CreateUser(user)
+	if session.RevokedAt != nil {
+		if _, err := u.sessionRepo.RevokeAllSessionsByUserID(session.UserID); err != nil {
+			return "", "", err
+		}
+
+		return "", "", errors.New("refresh token has been revoked")
+	}
+
+	if time.Now().After(session.ExpiresAt) {
+		return "", "", errors.New("refresh token is expired")
+	}
+
+	revoked, err := u.sessionRepo.RevokeSession(session.ID)
 	if err != nil {
-		return nil, err
+		return "", "", err
 	}
 
-	return createdUser, nil
-}
+	if revoked != 1 {
+		return "", "", errors.New("refresh token has been revoked")
+	}
 
-func (u *AuthUseCaseImpl) Login(email, password string) (string, *entities.User, error) {
-	normalizedEmail, err := utils.NormalizeEmail(email)
+	user, err := u.repo.GetUserByID(session.UserID)
 	if err != nil {
-		return "", nil, errors.New("invalid email format")
+		return "", "", err
 	}
 
-	email = normalizedEmail
-	user, err := u.repo.FindUserByEmail(email)
+	if user.DisabledAt != nil {
+		return "", "", errors.New("account is disabled")
+	}
+
+	return u.createSession(user, userAgent, ip)
+}
+
+func (u *AuthUseCaseImpl) Logout(sessionID string) error {
+	_, err := u.sessionRepo.RevokeSession(sessionID)
+	return err
+}
+
+func (u *AuthUseCaseImpl) RevokeAllSessions(userID string) (int64, error) {
+	if _, err := u.repo.GetUserByID(userID); err != nil {
+		return 0, err
+	}
+
+	return u.sessionRepo.RevokeAllSessionsByUserID(userID)
+}
+
+func (u *AuthUseCaseImpl) createSession(user *entities.User, userAgent, ip string) (string, string, error) {
+	refreshToken, err := utils.GenerateToken(32)
 	if err != nil {
-		return "", nil, errors.New("invalid email")
+		return "", "", err
 	}
 
-	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
-		return "", nil, errors.New("invalid password")
+	now := time.Now()
+	session := &entities.Session{
+		ID:           uuid.New().String(),
+		UserID:       user.ID,
+		RefreshToken: utils.HashToken(refreshToken),
+		UserAgent:    userAgent,
+		IP:           ip,
+		ExpiresAt:    now.Add(u.jwt.RefreshExpire),
+	}
+
+	if _, err := u.sessionRepo.CreateSession(session); err != nil {
+		return "", "", err
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"user_id": user.ID,
-		"role":    user.Role.RoleName,
+		"user_id":    user.ID,
+		"role":       user.Role.RoleName,
+		"session_id": session.ID,
+		"iat":        now.Unix(),
+		"exp":        now.Add(u.jwt.AccessExpire).Unix(),
 	})
 
-	tokenString, err := token.SignedString([]byte(u.jwtSecret))
+	tokenString, err := token.SignedString([]byte(u.jwt.Secret))
 	if err != nil {
-		return "", nil, err
+		return "", "", err
 	}
 
-	return tokenString, &user, nil
+	return tokenString, refreshToken, nil
 }
 
 func (u *AuthUseCaseImpl) ForgotPassword(email string) error {
@@ -102,22 +175,39 @@ func (u *AuthUseCaseImpl) ForgotPassword(email string) error {
 		return errors.New("invalid email")
 	}
 
-	userID := user.ID
-	otpCode, err := utils.GenerateRandomOTP(6, true)
-	if err != nil {
+	now := time.Now()
+	otp, err := u.repo.GetOTPByUserID(user.ID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		return err
 	}
 
-	expiresAt := time.Now().Add(5 * time.Minute)
-	u.repo.DeleteOTP(userID)
+	if otp != nil {
+		if otp.LockedUntil != nil && now.Before(*otp.LockedUntil) {
+			return errors.New("too many incorrect OTP attempts, please try again later")
+		}
+
+		if now.Sub(otp.IssuedAt) < otpResendWait {
+			return errors.New("please wait before requesting a new OTP")
+		}
 
-	newOTP := &entities.OTP{
-		UserID:    userID,
-		OTP:       otpCode,
-		ExpiresAt: expiresAt,
+		if otp.LockedUntil != nil {
+			otp.Attempts = 0
+			otp.LockedUntil = nil
+		}
+	}
+
+	otpCode, err := utils.GenerateRandomOTP(6, true)
+	if err != nil {
+		return err
 	}
 
-	if err := u.repo.CreateOTP(newOTP); err != nil {
+	if otp == nil {
+		otp = &entities.OTP{UserID: user.ID}
+		err = u.repo.CreateOTP(withOTPCode(otp, otpCode, now))
+	} else {
+		err = u.repo.UpdateOTP(withOTPCode(otp, otpCode, now))
+	}
+	if err != nil {
 		return err
 	}
 
@@ -128,38 +218,78 @@ func (u *AuthUseCaseImpl) ForgotPassword(email string) error {
 	return nil
 }
 
-func (u *AuthUseCaseImpl) VerifyOTP(email, otpCode string) error {
+func (u *AuthUseCaseImpl) VerifyOTP(email, otpCode string) (string, error) {
 	user, err := u.repo.FindUserByEmail(email)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	otp, err := u.repo.GetOTPByUserID(user.ID)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	if time.Now().After(otp.ExpiresAt) {
-		return errors.New("OTP is expired")
+	now := time.Now()
+	if otp.LockedUntil != nil && now.Before(*otp.LockedUntil) {
+		return "", errors.New("too many incorrect OTP attempts, please try again later")
 	}
 
-	if otp.OTP != otpCode {
-		return errors.New("OTP is incorrect")
+	if otp.OTP == "" || now.After(otp.ExpiresAt) {
+		return "", errors.New("OTP is expired")
 	}
 
-	if err := u.repo.DeleteOTP(user.ID); err != nil {
-		return err
+	if subtle.ConstantTimeCompare([]byte(otp.OTP), []byte(hashOTP(user.ID, otpCode))) != 1 {
+		otp.Attempts++
+		if otp.Attempts >= otpMaxAttempts {
+			lockedUntil := now.Add(otpLockDuration)
+			otp.LockedUntil = &lockedUntil
+			otp.OTP = ""
+		}
+
+		if err := u.repo.UpdateOTP(otp); err != nil {
+			return "", err
+		}
+
+		return "", errors.New("OTP is incorrect")
 	}
 
-	return nil
+	resetToken, err := utils.GenerateToken(32)
+	if err != nil {
+		return "", err
+	}
+
+	resetExpiresAt := now.Add(resetTokenExpire)
+	otp.OTP = ""
+	otp.Attempts = 0
+	otp.LockedUntil = nil
+	otp.ResetToken = utils.HashToken(resetToken)
+	otp.ResetExpiresAt = &resetExpiresAt
+	if err := u.repo.UpdateOTP(otp); err != nil {
+		return "", err
+	}
+
+	return resetToken, nil
 }
 
-func (u *AuthUseCaseImpl) ChangedPassword(email, newPassword string) error {
+func (u *AuthUseCaseImpl) ChangedPassword(email, resetToken, newPassword string) error {
 	user, err := u.repo.FindUserByEmail(email)
 	if err != nil {
 		return err
 	}
 
+	otp, err := u.repo.GetOTPByUserID(user.ID)
+	if err != nil {
+		return errors.New("invalid reset token")
+	}
+
+	if otp.ResetToken == "" || subtle.ConstantTimeCompare([]byte(otp.ResetToken), []byte(utils.HashToken(resetToken))) != 1 {
+		return errors.New("invalid reset token")
+	}
+
+	if otp.ResetExpiresAt == nil || time.Now().After(*otp.ResetExpiresAt) {
+		return errors.New("reset token is expired")
+	}
+
 	if err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(newPassword)); err == nil {
 		return errors.New("new password cannot be the same as the old password")
 	}
@@ -175,5 +305,26 @@ func (u *AuthUseCaseImpl) ChangedPassword(email, newPassword string) error {
 		return err
 	}
 
+	if err := u.repo.DeleteOTP(user.ID); err != nil {
+		return err
+	}
+
+	if _, err := u.sessionRepo.RevokeAllSessionsByUserID(user.ID); err != nil {
+		return err
+	}
+
 	return nil
 }
+
+func withOTPCode(otp *entities.OTP, otpCode string, now time.Time) *entities.OTP {
+	otp.OTP = hashOTP(otp.UserID, otpCode)
+	otp.ExpiresAt = now.Add(otpExpire)
+	otp.IssuedAt = now
+	otp.ResetToken = ""
+	otp.ResetExpiresAt = nil
+	return otp
+}
+
+func hashOTP(userID, otpCode string) string {
+	return utils.HashToken(userID + ":" + otpCode)
+}
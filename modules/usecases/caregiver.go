@@ -0,0 +1,168 @@
+package usecases
+
+import (
+	"Beside-Mom-BE/configs"
+	"Beside-Mom-BE/modules/entities"
+	"Beside-Mom-BE/modules/repositories"
+	"Beside-Mom-BE/pkg/utils"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CaregiverUseCase interface {
+	InviteCaregiver(kidID string, inviterID string, user *entities.User, relation string, access string) (*entities.KidCaregiver, error)
+	GetCaregivers(kidID string) ([]entities.KidCaregiver, error)
+	UpdateCaregiverAccess(kidID string, id string, access string) (*entities.KidCaregiver, error)
+	RemoveCaregiver(kidID string, id string) error
+	GetSharedKids(userID string) ([]map[string]interface{}, error)
+}
+
+type CaregiverUseCaseImpl struct {
+	repo       repositories.CaregiverRepository
+	userRepo   repositories.UserRepository
+	kidRepo    repositories.KidsRepository
+	inviteRepo repositories.InviteRepository
+	jwt        configs.JWT
+	mail       configs.Mail
+}
+
+func NewCaregiverUseCase(repo repositories.CaregiverRepository, userRepo repositories.UserRepository, kidRepo repositories.KidsRepository, inviteRepo repositories.InviteRepository, jwt configs.JWT, mail configs.Mail) *CaregiverUseCaseImpl {
+	return &CaregiverUseCaseImpl{
+		repo:       repo,
+		userRepo:   userRepo,
+		kidRepo:    kidRepo,
+		inviteRepo: inviteRepo,
+		jwt:        jwt,
+		mail:       mail,
+	}
+}
+
+func (u *CaregiverUseCaseImpl) InviteCaregiver(kidID string, inviterID string, user *entities.User, relation string, access string) (*entities.KidCaregiver, error) {
+	if !validCaregiverAccess(access) {
+		return nil, errors.New("access must be view or record_growth")
+	}
+
+	ownerID, err := u.kidRepo.GetKidOwnerID(kidID)
+	if err != nil {
+		return nil, err
+	}
+
+	normalizedEmail, err := utils.NormalizeEmail(user.Email)
+	if err != nil {
+		return nil, errors.New("invalid email format")
+	}
+
+	existingUser, err := u.userRepo.FindUserByEmail(normalizedEmail)
+	caregiverUser := &existingUser
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	} else if err != nil {
+		role, err := u.userRepo.GetRoleByName("User")
+		if err != nil {
+			return nil, errors.New("role not found")
+		}
+
+		user.ID = uuid.New().String()
+		user.PID = "Caregiver-" + user.ID[:8]
+		user.Email = normalizedEmail
+		user.RoleID = role.ID
+		user.Status = "pending"
+		caregiverUser, err = u.userRepo.CreateUser(user)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := issueInvite(u.inviteRepo, caregiverUser, u.jwt, u.mail); err != nil {
+			return nil, err
+		}
+	} else if caregiverUser.Role.RoleName != "User" {
+		return nil, errors.New("staff accounts cannot be added as caregivers")
+	}
+
+	if caregiverUser.ID == ownerID {
+		return nil, errors.New("the mom already owns this kid")
+	}
+
+	if _, err := u.repo.GetActiveCaregiver(kidID, caregiverUser.ID); err == nil {
+		return nil, errors.New("this person is already a caregiver of the kid")
+	}
+
+	caregiver := &entities.KidCaregiver{
+		ID:        uuid.New().String(),
+		KidID:     kidID,
+		UserID:    caregiverUser.ID,
+		Access:    access,
+		Relation:  relation,
+		InvitedBy: inviterID,
+	}
+
+	return u.repo.CreateCaregiver(caregiver)
+}
+
+func (u *CaregiverUseCaseImpl) GetCaregivers(kidID string) ([]entities.KidCaregiver, error) {
+	return u.repo.GetCaregiversByKidID(kidID)
+}
+
+func (u *CaregiverUseCaseImpl) UpdateCaregiverAccess(kidID string, id string, access string) (*entities.KidCaregiver, error) {
+	if !validCaregiverAccess(access) {
+		return nil, errors.New("access must be view or record_growth")
+	}
+
+	caregiver, err := u.getKidCaregiver(kidID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	caregiver.Access = access
+	return u.repo.UpdateCaregiver(caregiver)
+}
+
+func (u *CaregiverUseCaseImpl) RemoveCaregiver(kidID string, id string) error {
+	if _, err := u.getKidCaregiver(kidID, id); err != nil {
+		return err
+	}
+
+	return u.repo.RevokeCaregiver(id)
+}
+
+func (u *CaregiverUseCaseImpl) GetSharedKids(userID string) ([]map[string]interface{}, error) {
+	caregivers, err := u.repo.GetSharedKidsByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var kids []map[string]interface{}
+	for _, c := range caregivers {
+		kids = append(kids, map[string]interface{}{
+			"id":        c.Kid.ID,
+			"firstname": c.Kid.Firstname,
+			"lastname":  c.Kid.Lastname,
+			"username":  c.Kid.Username,
+			"imagelink": c.Kid.ImageLink,
+			"birthdate": c.Kid.BirthDate,
+			"access":    c.Access,
+			"relation":  c.Relation,
+		})
+	}
+
+	return kids, nil
+}
+
+func (u *CaregiverUseCaseImpl) getKidCaregiver(kidID string, id string) (*entities.KidCaregiver, error) {
+	caregiver, err := u.repo.GetCaregiverByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if caregiver.KidID != kidID || caregiver.RevokedAt != nil {
+		return nil, errors.New("caregiver not found")
+	}
+
+	return caregiver, nil
+}
+
+func validCaregiverAccess(access string) bool {
+	return access == "view" || access == "record_growth"
+}
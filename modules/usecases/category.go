@@ -0,0 +1,102 @@
+package usecases
+
+import (
+	"Beside-Mom-BE/modules/entities"
+	"Beside-Mom-BE/modules/repositories"
+	"errors"
+	"strings"
+)
+
+type CategoryUseCase interface {
+	GetAllCategories(includeRetired bool) ([]entities.Category, error)
+	CreateCategory(name string, minPassed int) (*entities.Category, error)
+	RenameCategory(id int, name string) (*entities.Category, error)
+	UpdateCategoryRule(id int, minPassed int) (*entities.Category, error)
+	ReorderCategories(ids []int) ([]entities.Category, error)
+	RetireCategory(id int) error
+}
+
+type CategoryUseCaseImpl struct {
+	repo repositories.CategoryRepository
+}
+
+func NewCategoryUseCase(repo repositories.CategoryRepository) *CategoryUseCaseImpl {
+	return &CategoryUseCaseImpl{repo: repo}
+}
+
+func (u *CategoryUseCaseImpl) GetAllCategories(includeRetired bool) ([]entities.Category, error) {
+	return u.repo.GetAllCategories(includeRetired)
+}
+
+func (u *CategoryUseCaseImpl) CreateCategory(name string, minPassed int) (*entities.Category, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("category name is required")
+	}
+
+	if minPassed < 0 {
+		return nil, errors.New("min_passed must not be negative")
+	}
+
+	return u.repo.CreateCategory(&entities.Category{Category: name, MinPassed: minPassed})
+}
+
+func (u *CategoryUseCaseImpl) RenameCategory(id int, name string) (*entities.Category, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("category name is required")
+	}
+
+	if _, err := u.repo.GetCategoryByID(id); err != nil {
+		return nil, errors.New("category not found")
+	}
+
+	return u.repo.RenameCategory(id, name)
+}
+
+func (u *CategoryUseCaseImpl) UpdateCategoryRule(id int, minPassed int) (*entities.Category, error) {
+	if minPassed < 0 {
+		return nil, errors.New("min_passed must not be negative")
+	}
+
+	if _, err := u.repo.GetCategoryByID(id); err != nil {
+		return nil, errors.New("category not found")
+	}
+
+	return u.repo.UpdateCategoryRule(id, minPassed)
+}
+
+func (u *CategoryUseCaseImpl) ReorderCategories(ids []int) ([]entities.Category, error) {
+	categories, err := u.repo.GetAllCategories(true)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make([]int, 0, len(categories))
+	for _, c := range categories {
+		existing = append(existing, c.ID)
+	}
+
+	if err := validateOrder(ids, existing); err != nil {
+		return nil, err
+	}
+
+	if err := u.repo.ReorderCategories(ids); err != nil {
+		return nil, err
+	}
+
+	return u.repo.GetAllCategories(false)
+}
+
+func (u *CategoryUseCaseImpl) RetireCategory(id int) error {
+	category, err := u.repo.GetCategoryByID(id)
+	if err != nil {
+		return errors.New("category not found")
+	}
+
+	if category.RetiredAt != nil {
+		return errors.New("category is already retired")
+	}
+
+	return u.repo.RetireCategory(id)
+}
@@ -3,6 +3,8 @@ package usecases
 import (
 	"Beside-Mom-BE/modules/entities"
 	"Beside-Mom-BE/modules/repositories"
+	"Beside-Mom-BE/pkg/utils"
+	"time"
 )
 
 type EvaluateUseCaseImpl struct {
@@ -10,13 +12,85 @@ type EvaluateUseCaseImpl struct {
 }
 
 type EvaluateUseCase interface {
-	GetAllEvaluate(kidID string) ([]entities.Evaluate, error)
+	GetAllEvaluate(kidID string, lang string) ([]entities.Evaluate, error)
+	GetOverdueKids() ([]map[string]interface{}, error)
 }
 
 func NewEvaluateUseCase(repo repositories.EvaluateRepository) *EvaluateUseCaseImpl {
 	return &EvaluateUseCaseImpl{repo: repo}
 }
 
-func (u *EvaluateUseCaseImpl) GetAllEvaluate(kidID string) ([]entities.Evaluate, error) {
-	return u.repo.GetAllEvaluate(kidID)
+func (u *EvaluateUseCaseImpl) GetAllEvaluate(kidID string, lang string) ([]entities.Evaluate, error) {
+	evaluates, err := u.repo.GetAllEvaluate(kidID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for i := range evaluates {
+		evaluates[i].State = screeningState(evaluates[i].Status, evaluates[i].DueFrom, evaluates[i].DueTo, now)
+		evaluates[i].Solution = utils.EvaluateResultLabel(evaluates[i].Result, lang)
+		evaluates[i].Sequence = i + 1
+	}
+
+	return evaluates, nil
+}
+
+func (u *EvaluateUseCaseImpl) GetOverdueKids() ([]map[string]interface{}, error) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	evaluates, err := u.repo.GetOverdueEvaluates(today)
+	if err != nil {
+		return nil, err
+	}
+
+	kids := []map[string]interface{}{}
+	index := make(map[string]int)
+	for _, e := range evaluates {
+		i, found := index[e.KidID]
+		if !found {
+			i = len(kids)
+			index[e.KidID] = i
+			kids = append(kids, map[string]interface{}{
+				"kid_id":    e.Kid.ID,
+				"firstname": e.Kid.Firstname,
+				"lastname":  e.Kid.Lastname,
+				"username":  e.Kid.Username,
+				"user_id":   e.Kid.UserID,
+				"overdue":   []map[string]interface{}{},
+			})
+		}
+
+		kids[i]["overdue"] = append(kids[i]["overdue"].([]map[string]interface{}), map[string]interface{}{
+			"evaluate_id":    e.ID,
+			"evaluate_times": e.EvaluatedTimes,
+			"period":         e.Period.Period,
+			"due_from":       e.DueFrom,
+			"due_to":         e.DueTo,
+			"days_overdue":   int(today.Sub(*e.DueTo).Hours() / 24),
+		})
+	}
+
+	return kids, nil
+}
+
+func screeningState(completed bool, dueFrom *time.Time, dueTo *time.Time, now time.Time) string {
+	if completed {
+		return entities.EvaluateStateCompleted
+	}
+
+	if dueFrom == nil || dueTo == nil {
+		return entities.EvaluateStateUpcoming
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, dueTo.Location())
+	if today.Before(*dueFrom) {
+		return entities.EvaluateStateUpcoming
+	}
+
+	if today.After(*dueTo) {
+		return entities.EvaluateStateOverdue
+	}
+
+	return entities.EvaluateStateDue
 }
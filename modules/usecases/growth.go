@@ -3,37 +3,62 @@ package usecases
 import (
 	"Beside-Mom-BE/modules/entities"
 	"Beside-Mom-BE/modules/repositories"
+	"Beside-Mom-BE/pkg/growth"
 	"Beside-Mom-BE/pkg/utils"
+	"bytes"
+	"encoding/csv"
 	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
 type GrowthUseCaseImpl struct {
-	repo    repositories.GrowthRepository
-	kidRepo repositories.KidsRepository
+	repo      repositories.GrowthRepository
+	kidRepo   repositories.KidsRepository
+	alertRepo repositories.GrowthAlertRepository
 }
 
 type GrowthUseCase interface {
-	CreateGrowth(kidID string, growth *entities.Growth, date time.Time) (*entities.Growth, error)
+	CreateGrowth(kidID string, growth *entities.Growth, date time.Time, ctx *fiber.Ctx) (*entities.Growth, error)
 	GetSummary(kidID string) ([]map[string]interface{}, error)
 	GetAllGrowth(kidID string) ([]entities.Growth, error)
-	UpdateGrowthByID(id string, growth *entities.Growth) (*entities.Growth, error)
+	UpdateGrowthByID(id string, growth *entities.Growth, ctx *fiber.Ctx) (*entities.Growth, error)
+	GetAlerts(severity string) ([]entities.GrowthAlert, error)
+	GetChart(kidID string, metric string, format string) ([]byte, error)
+	ImportGrowth(file io.Reader, ctx *fiber.Ctx) ([]entities.GrowthImportResult, error)
+	ExportGrowthCSV(kidID string) ([]byte, error)
+	ExportAllGrowthCSV() ([]byte, error)
 }
 
-func NewGrowthUseCase(repo repositories.GrowthRepository, kidRepo repositories.KidsRepository) *GrowthUseCaseImpl {
+var growthImportColumns = []string{"kid_id", "date", "length", "weight"}
+
+var growthExportColumns = []string{"kid_id", "date", "length", "weight", "head_circumference", "months", "corrected_months"}
+
+func NewGrowthUseCase(repo repositories.GrowthRepository, kidRepo repositories.KidsRepository, alertRepo repositories.GrowthAlertRepository) *GrowthUseCaseImpl {
 	return &GrowthUseCaseImpl{
-		repo:    repo,
-		kidRepo: kidRepo,
+		repo:      repo,
+		kidRepo:   kidRepo,
+		alertRepo: alertRepo,
 	}
 }
 
-func (u *GrowthUseCaseImpl) CreateGrowth(kidID string, growth *entities.Growth, date time.Time) (*entities.Growth, error) {
+func (u *GrowthUseCaseImpl) CreateGrowth(kidID string, growth *entities.Growth, date time.Time, ctx *fiber.Ctx) (*entities.Growth, error) {
 	if growth.Length <= 0 || growth.Weight <= 0 {
 		return nil, errors.New("length and weight must be positive numbers")
 	}
 
+	if growth.HeadCircumference != nil && *growth.HeadCircumference <= 0 {
+		return nil, errors.New("head circumference must be a positive number")
+	}
+
 	kid, err := u.kidRepo.GetKidByID(kidID)
 	if err != nil {
 		return nil, err
@@ -44,16 +69,26 @@ func (u *GrowthUseCaseImpl) CreateGrowth(kidID string, growth *entities.Growth,
 		return nil, err
 	}
 
+	correctedMonths, err := utils.CompareCorrectedAgeKid(kid.BirthDate, kid.BeforeBirth, date)
+	if err != nil {
+		return nil, err
+	}
+
 	growth.Months = months
+	growth.CorrectedMonths = correctedMonths
 	growth.CreatedAt = date
 	growth.UpdatedAt = date
 	existingGrowth, err := u.repo.GetLatestGrowthByKidID(kidID, months)
 	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
-		createdGrowth, err := u.repo.CreateGrowth(growth)
+		createdGrowth, err := u.repo.WithContext(ctx.UserContext()).CreateGrowth(growth)
 		if err != nil {
 			return nil, err
 		}
 
+		if err := u.evaluateAlerts(kid, createdGrowth); err != nil {
+			log.Printf("Failed to evaluate growth alerts for %s: %v", createdGrowth.ID, err)
+		}
+
 		return createdGrowth, nil
 	} else if err != nil {
 		return nil, err
@@ -61,24 +96,83 @@ func (u *GrowthUseCaseImpl) CreateGrowth(kidID string, growth *entities.Growth,
 
 	existingGrowth.Length = growth.Length
 	existingGrowth.Weight = growth.Weight
+	if growth.HeadCircumference != nil {
+		existingGrowth.HeadCircumference = growth.HeadCircumference
+	}
+	existingGrowth.CorrectedMonths = correctedMonths
 	existingGrowth.CreatedAt = date
-	updatedGrowth, err := u.repo.UpdateGrowth(existingGrowth)
+	updatedGrowth, err := u.repo.WithContext(ctx.UserContext()).UpdateGrowth(existingGrowth)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := u.evaluateAlerts(kid, updatedGrowth); err != nil {
+		log.Printf("Failed to evaluate growth alerts for %s: %v", updatedGrowth.ID, err)
+	}
+
 	return updatedGrowth, nil
 }
 
 func (u *GrowthUseCaseImpl) GetSummary(kidID string) ([]map[string]interface{}, error) {
-	return u.repo.GetSummary(kidID)
+	summary, err := u.repo.GetSummary(kidID)
+	if err != nil {
+		return nil, err
+	}
+
+	kid, err := u.kidRepo.GetKidByID(kidID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, point := range summary {
+		date := point["date"].(time.Time)
+		ageDays := date.Sub(kid.BirthDate).Hours() / 24
+		measurement := growth.Measurement{
+			Length:            point["length"].(float64),
+			Weight:            point["weight"].(float64),
+			HeadCircumference: point["head_circumference"].(*float64),
+		}
+
+		assessment, err := growth.Assess(kid.Sex, kid.BeforeBirth, ageDays, measurement)
+		if errors.Is(err, growth.ErrFentonUnavailable) {
+			return nil, err
+		}
+		if err != nil {
+			assessment = &growth.Assessment{}
+		}
+
+		correctedMonths, err := utils.CompareCorrectedAgeKid(kid.BirthDate, kid.BeforeBirth, date)
+		if err != nil {
+			return nil, err
+		}
+
+		point["corrected_months"] = correctedMonths
+		point["reference"] = assessment.Reference
+		point["post_menstrual_weeks"] = assessment.PostMenstrualWeeks
+		point["weight_for_age"] = assessment.WeightForAge
+		point["length_for_age"] = assessment.LengthForAge
+		point["weight_for_length"] = assessment.WeightForLength
+		point["bmi"] = growth.BMI(measurement.Weight, measurement.Length)
+		point["bmi_for_age"] = assessment.BMIForAge
+		point["head_circumference_for_age"] = assessment.HeadForAge
+	}
+
+	return summary, nil
 }
 
 func (u *GrowthUseCaseImpl) GetAllGrowth(kidID string) ([]entities.Growth, error) {
 	return u.repo.GetAllGrowth(kidID)
 }
 
-func (u *GrowthUseCaseImpl) UpdateGrowthByID(id string, growth *entities.Growth) (*entities.Growth, error) {
+func (u *GrowthUseCaseImpl) UpdateGrowthByID(id string, growth *entities.Growth, ctx *fiber.Ctx) (*entities.Growth, error) {
+	if growth.Length <= 0 || growth.Weight <= 0 {
+		return nil, errors.New("length and weight must be positive numbers")
+	}
+
+	if growth.HeadCircumference != nil && *growth.HeadCircumference <= 0 {
+		return nil, errors.New("head circumference must be a positive number")
+	}
+
 	existingGrowth, err := u.repo.GetGrowthByID(id)
 	if err != nil {
 		return nil, err
@@ -86,11 +180,317 @@ func (u *GrowthUseCaseImpl) UpdateGrowthByID(id string, growth *entities.Growth)
 
 	existingGrowth.Length = growth.Length
 	existingGrowth.Weight = growth.Weight
+	if growth.HeadCircumference != nil {
+		existingGrowth.HeadCircumference = growth.HeadCircumference
+	}
 	existingGrowth.UpdatedAt = growth.CreatedAt
-	updatedGrowth, err := u.repo.UpdateGrowth(existingGrowth)
+	updatedGrowth, err := u.repo.WithContext(ctx.UserContext()).UpdateGrowth(existingGrowth)
+	if err != nil {
+		return nil, err
+	}
+
+	kid, err := u.kidRepo.GetKidByID(updatedGrowth.KidID)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := u.evaluateAlerts(kid, updatedGrowth); err != nil {
+		log.Printf("Failed to evaluate growth alerts for %s: %v", updatedGrowth.ID, err)
+	}
+
 	return updatedGrowth, nil
 }
+
+func (u *GrowthUseCaseImpl) GetAlerts(severity string) ([]entities.GrowthAlert, error) {
+	return u.alertRepo.GetAlerts(severity)
+}
+
+func (u *GrowthUseCaseImpl) GetChart(kidID string, metric string, format string) ([]byte, error) {
+	if !growth.IsChartMetric(metric) {
+		return nil, errors.New("metric must be one of weight, length, head or bmi")
+	}
+
+	if format != "svg" && format != "png" {
+		return nil, errors.New("format must be svg or png")
+	}
+
+	summary, err := u.GetSummary(kidID)
+	if err != nil {
+		return nil, err
+	}
+
+	kid, err := u.kidRepo.GetKidByID(kidID)
+	if err != nil {
+		return nil, err
+	}
+
+	var points []growth.ChartPoint
+	for _, point := range summary {
+		if point["reference"] == growth.ReferenceFenton {
+			continue
+		}
+
+		ageDays := point["date"].(time.Time).Sub(kid.BirthDate).Hours() / 24
+		if growth.IsPreterm(kid.BeforeBirth) {
+			ageDays = growth.CorrectedAgeDays(kid.BeforeBirth, ageDays)
+		}
+
+		var value float64
+		switch metric {
+		case "weight":
+			value = point["weight"].(float64)
+		case "length":
+			value = point["length"].(float64)
+		case "bmi":
+			value = point["bmi"].(float64)
+		case "head":
+			head := point["head_circumference"].(*float64)
+			if head == nil {
+				continue
+			}
+			value = *head
+		}
+
+		points = append(points, growth.ChartPoint{X: growth.AgeInMonths(ageDays), Y: value})
+	}
+
+	chart, err := growth.BuildChart(metric, kid.Sex, points)
+	if err != nil {
+		return nil, err
+	}
+
+	if format == "png" {
+		return growth.RenderPNG(chart)
+	}
+
+	return growth.RenderSVG(chart), nil
+}
+
+func (u *GrowthUseCaseImpl) ImportGrowth(file io.Reader, ctx *fiber.Ctx) ([]entities.GrowthImportResult, error) {
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.New("CSV file is empty or unreadable")
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+
+	for _, name := range growthImportColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("CSV is missing the %s column", name)
+		}
+	}
+
+	results := []entities.GrowthImportResult{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			results = append(results, entities.GrowthImportResult{Row: parseErr.Line, Status: "error", Message: parseErr.Err.Error()})
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		field := func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		result := entities.GrowthImportResult{Row: line, KidID: field("kid_id"), Date: field("date")}
+		growth, date, err := parseGrowthImportRow(field)
+		if err != nil {
+			result.Status = "error"
+			result.Message = err.Error()
+			results = append(results, result)
+			continue
+		}
+
+		saved, err := u.CreateGrowth(result.KidID, growth, date, ctx)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			result.Status = "error"
+			result.Message = "kid not found"
+		} else if err != nil {
+			result.Status = "error"
+			result.Message = err.Error()
+		} else if saved.ID == growth.ID {
+			result.Status = "created"
+			result.GrowthID = saved.ID
+		} else {
+			result.Status = "updated"
+			result.GrowthID = saved.ID
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func (u *GrowthUseCaseImpl) ExportGrowthCSV(kidID string) ([]byte, error) {
+	if _, err := u.kidRepo.GetKidByID(kidID); err != nil {
+		return nil, err
+	}
+
+	growths, err := u.repo.GetAllGrowth(kidID)
+	if err != nil {
+		return nil, err
+	}
+
+	return writeGrowthCSV(growths)
+}
+
+func (u *GrowthUseCaseImpl) ExportAllGrowthCSV() ([]byte, error) {
+	growths, err := u.repo.GetAllGrowthRecords()
+	if err != nil {
+		return nil, err
+	}
+
+	return writeGrowthCSV(growths)
+}
+
+func parseGrowthImportRow(field func(string) string) (*entities.Growth, time.Time, error) {
+	if field("kid_id") == "" {
+		return nil, time.Time{}, errors.New("kid_id is required")
+	}
+
+	if field("length") == "" || field("weight") == "" {
+		return nil, time.Time{}, errors.New("length and weight are required")
+	}
+
+	date, err := time.Parse("2006-01-02", field("date"))
+	if err != nil {
+		return nil, time.Time{}, errors.New("invalid date format. Use YYYY-MM-DD")
+	}
+
+	length, err := strconv.ParseFloat(field("length"), 64)
+	if err != nil {
+		return nil, time.Time{}, errors.New("invalid length value")
+	}
+
+	weight, err := strconv.ParseFloat(field("weight"), 64)
+	if err != nil {
+		return nil, time.Time{}, errors.New("invalid weight value")
+	}
+
+	growth := &entities.Growth{
+		ID:     uuid.New().String(),
+		Length: length,
+		Weight: weight,
+		KidID:  field("kid_id"),
+	}
+
+	if value := field("head_circumference"); value != "" {
+		headCircumference, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, time.Time{}, errors.New("invalid head circumference value")
+		}
+		growth.HeadCircumference = &headCircumference
+	}
+
+	return growth, date, nil
+}
+
+func writeGrowthCSV(growths []entities.Growth) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	w := csv.NewWriter(buf)
+	if err := w.Write(growthExportColumns); err != nil {
+		return nil, err
+	}
+
+	for _, g := range growths {
+		headCircumference := ""
+		if g.HeadCircumference != nil {
+			headCircumference = strconv.FormatFloat(*g.HeadCircumference, 'f', -1, 64)
+		}
+
+		record := []string{
+			g.KidID,
+			g.CreatedAt.Format("2006-01-02"),
+			strconv.FormatFloat(g.Length, 'f', -1, 64),
+			strconv.FormatFloat(g.Weight, 'f', -1, 64),
+			headCircumference,
+			strconv.Itoa(g.Months),
+			strconv.Itoa(g.CorrectedMonths),
+		}
+
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (u *GrowthUseCaseImpl) evaluateAlerts(kid *entities.Kid, saved *entities.Growth) error {
+	records, err := u.repo.GetAllGrowth(kid.ID)
+	if err != nil {
+		return err
+	}
+
+	series := []growth.Point{{Date: kid.BirthDate, Weight: kid.BirthWeight}}
+	measurements := []growth.Measurement{{Length: kid.BirthLength, Weight: kid.BirthWeight}}
+	references := []string{""}
+	for _, record := range records {
+		measurements = append(measurements, growth.Measurement{Length: record.Length, Weight: record.Weight, HeadCircumference: record.HeadCircumference})
+		series = append(series, growth.Point{GrowthID: record.ID, Date: record.CreatedAt, Weight: record.Weight})
+		references = append(references, "")
+	}
+
+	index := -1
+	for i := range series {
+		ageDays := series[i].Date.Sub(kid.BirthDate).Hours() / 24
+		if assessment, err := growth.Assess(kid.Sex, kid.BeforeBirth, ageDays, measurements[i]); err == nil && assessment.WeightForAge != nil {
+			z := assessment.WeightForAge.Z
+			series[i].WeightZ = &z
+			references[i] = assessment.Reference
+		}
+
+		if series[i].GrowthID == saved.ID {
+			index = i
+		}
+	}
+
+	if index == -1 {
+		return nil
+	}
+
+	for i := range series {
+		if references[i] != references[index] {
+			series[i].WeightZ = nil
+		}
+	}
+
+	var alerts []entities.GrowthAlert
+	for _, finding := range growth.EvaluateAlerts(series[:index+1], index, growth.DefaultRules) {
+		alerts = append(alerts, entities.GrowthAlert{
+			ID:       uuid.New().String(),
+			KidID:    kid.ID,
+			GrowthID: saved.ID,
+			Rule:     finding.Rule,
+			Severity: finding.Severity,
+			Message:  finding.Message,
+		})
+	}
+
+	return u.alertRepo.ReplaceAlertsForGrowth(saved.ID, alerts)
+}
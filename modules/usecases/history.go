@@ -1,33 +1,57 @@
 package usecases
 
 import (
+	"Beside-Mom-BE/configs"
 	"Beside-Mom-BE/modules/entities"
 	"Beside-Mom-BE/modules/repositories"
+	"Beside-Mom-BE/pkg/utils"
 	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
 
+	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const referralRetestMonths = 1
+
+const (
+	historyImageMaxBytes = 10 << 20
+	historyVideoMaxBytes = 200 << 20
 )
 
 type HistoryUseCase interface {
-	CreateHistoryInPeriodandHistory(evaluateTimes int, cate int, kidID string, answers []bool) error
-	GetHistoryOfEvaluate(times int, kidID string) (map[string]map[int]entities.GroupedHistory, error)
+	CreateHistoryInPeriodandHistory(evaluateTimes int, cate int, kidID string, answers []bool, notes []string, media []*multipart.FileHeader, ctx *fiber.Ctx) error
+	GetHistoryOfEvaluate(times int, kidID string, lang string) (map[string]map[int]entities.GroupedHistory, error)
 	GetLatestHistoryOfEvaluate(times int, kidID string, cate int) ([]entities.History, error)
-	GetHistoryResult(evaluatedTimes int, kidID string) (map[int]entities.GroupedHistory, error)
+	GetHistoryResult(evaluatedTimes int, kidID string, lang string) (map[int]entities.GroupedHistory, error)
 }
 
 type HistoryUseCaseImpl struct {
-	repo    repositories.HistoryRepository
-	evaRepo repositories.EvaluateRepository
+	repo         repositories.HistoryRepository
+	evaRepo      repositories.EvaluateRepository
+	referralRepo repositories.ReferralRepository
+	supa         configs.Supabase
 }
 
-func NewHistoryUseCase(repo repositories.HistoryRepository, evaRepo repositories.EvaluateRepository) *HistoryUseCaseImpl {
+func NewHistoryUseCase(repo repositories.HistoryRepository, evaRepo repositories.EvaluateRepository, referralRepo repositories.ReferralRepository, supa configs.Supabase) *HistoryUseCaseImpl {
 	return &HistoryUseCaseImpl{
-		repo:    repo,
-		evaRepo: evaRepo,
+		repo:         repo,
+		evaRepo:      evaRepo,
+		referralRepo: referralRepo,
+		supa:         supa,
 	}
 }
 
-func (u *HistoryUseCaseImpl) CreateHistoryInPeriodandHistory(evaluateTimes int, cate int, kidID string, answers []bool) error {
+func (u *HistoryUseCaseImpl) CreateHistoryInPeriodandHistory(evaluateTimes int, cate int, kidID string, answers []bool, notes []string, media []*multipart.FileHeader, ctx *fiber.Ctx) error {
 	data, err := u.repo.GetLatestHistoryPerQuiz(evaluateTimes, cate, kidID)
 	if err != nil {
 		return err
@@ -37,12 +61,45 @@ func (u *HistoryUseCaseImpl) CreateHistoryInPeriodandHistory(evaluateTimes int,
 		return errors.New("please answer all quizzes")
 	}
 
-	err = u.repo.DeleteHistoryWithTimes(evaluateTimes, kidID, 0, cate)
-	if err != nil {
-		return err
+	contentTypes := make([]string, len(media))
+	for i, m := range media {
+		if m == nil {
+			continue
+		}
+
+		contentType, err := validateHistoryMedia(m)
+		if err != nil {
+			return err
+		}
+		contentTypes[i] = contentType
 	}
 
+	links := make([]string, len(answers))
+	for i, m := range media {
+		if m == nil {
+			continue
+		}
+
+		link, err := u.uploadHistoryMedia(m, contentTypes[i])
+		if err != nil {
+			u.deleteHistoryMedia(links)
+			return err
+		}
+		links[i] = link
+	}
+
+	minPassed := 0
+	if len(data) > 0 {
+		minPassed = data[0].Quiz.Category.MinPassed
+	}
+
+	passed := 0
+	histories := make([]entities.History, 0, len(data))
 	for i, d := range data {
+		if answers[i] {
+			passed++
+		}
+
 		history := entities.History{
 			ID:             uuid.New().String(),
 			QuizID:         d.QuizID,
@@ -51,61 +108,239 @@ func (u *HistoryUseCaseImpl) CreateHistoryInPeriodandHistory(evaluateTimes int,
 			EvaluatedTimes: d.EvaluatedTimes,
 			Times:          d.Times + 1,
 			KidID:          d.KidID,
+			Note:           strings.TrimSpace(notes[i]),
+			MinPassed:      &minPassed,
 		}
 
-		err = u.repo.CreateHistory(history)
+		if media[i] != nil {
+			history.MediaLink = links[i]
+			history.MediaType = historyMediaType(contentTypes[i])
+		}
+
+		histories = append(histories, history)
+	}
+
+	categoryFailed := len(data) > 0 && !utils.CategoryPassed(passed, len(data), minPassed)
+	err = u.repo.WithContext(ctx.UserContext()).Transaction(func(tx *gorm.DB) error {
+		repo := u.repo.WithTx(tx)
+		if err := repo.SubmitHistories(evaluateTimes, kidID, cate, histories); err != nil {
+			return err
+		}
+
+		if err := u.followUpCategory(u.referralRepo.WithTx(tx), evaluateTimes, cate, kidID, categoryFailed); err != nil {
+			return err
+		}
+
+		latestHistories, err := repo.GetLatestHistoryPerEvaluate(evaluateTimes, kidID)
 		if err != nil {
 			return err
 		}
-	}
 
-	latestHistories, err := u.repo.GetLatestHistoryPerEvaluate(evaluateTimes, kidID)
+		result, status := evaluateResult(latestHistories)
+		return u.evaRepo.WithTx(tx).UpdateEvaluate(evaluateTimes, kidID, result, status)
+	})
 	if err != nil {
+		u.deleteHistoryMedia(links)
 		return err
 	}
 
-	incomplete := false
-	failed := false
+	return nil
+}
+
+func evaluateResult(latestHistories []entities.History) (string, bool) {
+	byCategory := make(map[int][]entities.History)
 	for _, h := range latestHistories {
 		if !h.Status {
-			incomplete = true
-			break
+			return entities.ResultInProgress, false
+		}
+
+		byCategory[h.Quiz.CategoryID] = append(byCategory[h.Quiz.CategoryID], h)
+	}
+
+	for _, hs := range byCategory {
+		categoryPassed := 0
+		for _, h := range hs {
+			if h.Answer {
+				categoryPassed++
+			}
 		}
 
-		if !h.Answer {
-			failed = true
+		if !utils.CategoryPassed(categoryPassed, len(hs), utils.AppliedMinPassed(hs[0].MinPassed, hs[0].Quiz.Category.MinPassed)) {
+			return entities.ResultFailed, true
 		}
 	}
 
-	var result string
-	var status bool
-	if incomplete {
-		result = "กำลังประเมิน"
-		status = false
-	} else if failed {
-		result = "ไม่ผ่านการประเมินบางประการ"
-		status = true
-	} else {
-		result = "ผ่านการประเมิน"
-		status = true
+	return entities.ResultPassed, true
+}
+
+func (u *HistoryUseCaseImpl) uploadHistoryMedia(media *multipart.FileHeader, contentType string) (string, error) {
+	file, err := media.Open()
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	return utils.UploadFile("histories/"+uuid.New().String()+historyMediaExtension(media, contentType), file, contentType, u.supa)
+}
+
+func (u *HistoryUseCaseImpl) deleteHistoryMedia(links []string) {
+	for _, link := range links {
+		if link == "" {
+			continue
+		}
+
+		if err := utils.DeleteImage(link, u.supa); err != nil {
+			log.Printf("Failed to delete history media %s: %v", link, err)
+		}
 	}
+}
 
-	err = u.evaRepo.UpdateEvaluate(evaluateTimes, kidID, result, status)
+func validateHistoryMedia(media *multipart.FileHeader) (string, error) {
+	contentType, err := sniffHistoryMedia(media)
 	if err != nil {
+		return "", err
+	}
+
+	switch historyMediaType(contentType) {
+	case "image":
+		if media.Size > historyImageMaxBytes {
+			return "", fmt.Errorf("images must be %d MB or smaller", historyImageMaxBytes>>20)
+		}
+	case "video":
+		if media.Size > historyVideoMaxBytes {
+			return "", fmt.Errorf("videos must be %d MB or smaller", historyVideoMaxBytes>>20)
+		}
+	default:
+		return "", errors.New("media must be an image or a video")
+	}
+
+	return contentType, nil
+}
+
+func sniffHistoryMedia(media *multipart.FileHeader) (string, error) {
+	file, err := media.Open()
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+
+	return http.DetectContentType(head[:n]), nil
+}
+
+func historyMediaExtension(media *multipart.FileHeader, contentType string) string {
+	ext := strings.ToLower(filepath.Ext(media.Filename))
+	if ext != "" && strings.HasPrefix(mime.TypeByExtension(ext), historyMediaType(contentType)+"/") {
+		return ext
+	}
+
+	if exts, err := mime.ExtensionsByType(contentType); err == nil && len(exts) > 0 {
+		return exts[0]
+	}
+
+	return ""
+}
+
+func historyMediaType(contentType string) string {
+	switch {
+	case strings.HasPrefix(contentType, "image/"):
+		return "image"
+	case strings.HasPrefix(contentType, "video/"):
+		return "video"
+	default:
+		return ""
+	}
+}
+
+func (u *HistoryUseCaseImpl) followUpCategory(repo repositories.ReferralRepository, evaluateTimes int, cate int, kidID string, failed bool) error {
+	referral, err := repo.GetActiveReferral(kidID, evaluateTimes, cate)
+	if err == nil {
+		if referral.State != entities.ReferralStateOpen && referral.State != entities.ReferralStateRetested {
+			return nil
+		}
+
+		if referral.State == entities.ReferralStateRetested && !failed {
+			return nil
+		}
+
+		passed := !failed
+		referral.RetestPassed = &passed
+		if failed {
+			referral.State = entities.ReferralStateOpen
+			referral.RetestDueAt = time.Now().AddDate(0, referralRetestMonths, 0)
+		} else {
+			referral.State = entities.ReferralStateRetested
+		}
+
+		_, err = repo.UpdateReferral(referral)
 		return err
 	}
 
-	return nil
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	if !failed {
+		return nil
+	}
+
+	referral = &entities.Referral{
+		ID:             uuid.New().String(),
+		KidID:          kidID,
+		EvaluatedTimes: evaluateTimes,
+		CategoryID:     cate,
+		State:          entities.ReferralStateOpen,
+		RetestDueAt:    time.Now().AddDate(0, referralRetestMonths, 0),
+	}
+
+	nurseID, err := repo.GetLeastLoadedNurseID()
+	if err != nil {
+		return err
+	}
+
+	if nurseID != "" {
+		referral.AssigneeID = &nurseID
+	}
+
+	_, err = repo.CreateReferral(referral)
+	return err
 }
 
-func (u *HistoryUseCaseImpl) GetHistoryOfEvaluate(times int, kidID string) (map[string]map[int]entities.GroupedHistory, error) {
-	return u.repo.GetHistoryPerQuizGroupedByCategoryAndTimes(times, kidID)
+func (u *HistoryUseCaseImpl) GetHistoryOfEvaluate(times int, kidID string, lang string) (map[string]map[int]entities.GroupedHistory, error) {
+	result, err := u.repo.GetHistoryPerQuizGroupedByCategoryAndTimes(times, kidID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, groupByTimes := range result {
+		for t, g := range groupByTimes {
+			g.Solution = utils.CategoryResultLabel(g.Result, lang)
+			groupByTimes[t] = g
+		}
+	}
+
+	return result, nil
 }
 
 func (u *HistoryUseCaseImpl) GetLatestHistoryOfEvaluate(times int, kidID string, cate int) ([]entities.History, error) {
 	return u.repo.GetLatestHistoryPerQuiz(times, cate, kidID)
 }
 
-func (u *HistoryUseCaseImpl) GetHistoryResult(evaluatedTimes int, kidID string) (map[int]entities.GroupedHistory, error) {
-	return u.repo.GetHistoryResult(evaluatedTimes, kidID)
+func (u *HistoryUseCaseImpl) GetHistoryResult(evaluatedTimes int, kidID string, lang string) (map[int]entities.GroupedHistory, error) {
+	result, err := u.repo.GetHistoryResult(evaluatedTimes, kidID)
+	if err != nil {
+		return nil, err
+	}
+
+	for id, g := range result {
+		g.Solution = utils.CategoryResultLabel(g.Result, lang)
+		result[id] = g
+	}
+
+	return result, nil
 }
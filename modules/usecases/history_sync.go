@@ -0,0 +1,81 @@
+package usecases
+
+import (
+	"Beside-Mom-BE/modules/entities"
+	"Beside-Mom-BE/modules/repositories"
+	"Beside-Mom-BE/pkg/database"
+	"context"
+	"log"
+	"sync"
+)
+
+var historySyncActor = database.AuditActor{UserID: "history-sync", Role: "system"}
+
+type HistorySyncUseCase interface {
+	Start(ctx context.Context)
+	Trigger()
+	Preview() (*entities.HistorySyncReport, error)
+	LastReport() *entities.HistorySyncReport
+}
+
+type HistorySyncUseCaseImpl struct {
+	repo    repositories.HistoryRepository
+	trigger chan struct{}
+	once    sync.Once
+	mu      sync.RWMutex
+	last    *entities.HistorySyncReport
+}
+
+func NewHistorySyncUseCase(repo repositories.HistoryRepository) *HistorySyncUseCaseImpl {
+	return &HistorySyncUseCaseImpl{
+		repo:    repo,
+		trigger: make(chan struct{}, 1),
+	}
+}
+
+func (u *HistorySyncUseCaseImpl) Start(ctx context.Context) {
+	u.once.Do(func() {
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-u.trigger:
+					u.run(ctx)
+				}
+			}
+		}()
+		u.Trigger()
+	})
+}
+
+func (u *HistorySyncUseCaseImpl) Trigger() {
+	select {
+	case u.trigger <- struct{}{}:
+	default:
+	}
+}
+
+func (u *HistorySyncUseCaseImpl) Preview() (*entities.HistorySyncReport, error) {
+	return u.repo.SyncPlaceholderHistories(true)
+}
+
+func (u *HistorySyncUseCaseImpl) LastReport() *entities.HistorySyncReport {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	return u.last
+}
+
+func (u *HistorySyncUseCaseImpl) run(ctx context.Context) {
+	report, err := u.repo.WithContext(database.WithAuditActor(ctx, historySyncActor)).SyncPlaceholderHistories(false)
+	if err != nil {
+		log.Printf("History sync failed: %v", err)
+		report = &entities.HistorySyncReport{Error: err.Error()}
+	} else if len(report.Missing) > 0 || len(report.Orphaned) > 0 || len(report.Skipped) > 0 {
+		log.Printf("History sync created %d, removed %d and skipped %d placeholder histories", len(report.Missing), len(report.Orphaned), len(report.Skipped))
+	}
+
+	u.mu.Lock()
+	u.last = report
+	u.mu.Unlock()
+}
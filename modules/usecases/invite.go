@@ -0,0 +1,221 @@
+package usecases
+
+import (
+	"Beside-Mom-BE/configs"
+	"Beside-Mom-BE/modules/entities"
+	"Beside-Mom-BE/modules/repositories"
+	"Beside-Mom-BE/pkg/utils"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const inviteExpire = 72 * time.Hour
+
+type InviteUseCase interface {
+	AcceptInvite(token, password string) (*entities.User, error)
+	ResendInvite(userID string) (map[string]interface{}, error)
+	RevokeInvite(userID string) error
+	GetInviteByUserID(userID string) (map[string]interface{}, error)
+	GetAllInvites() ([]map[string]interface{}, error)
+}
+
+type InviteUseCaseImpl struct {
+	repo     repositories.InviteRepository
+	userRepo repositories.UserRepository
+	jwt      configs.JWT
+	mail     configs.Mail
+}
+
+func NewInviteUseCase(repo repositories.InviteRepository, userRepo repositories.UserRepository, jwt configs.JWT, mail configs.Mail) *InviteUseCaseImpl {
+	return &InviteUseCaseImpl{
+		repo:     repo,
+		userRepo: userRepo,
+		jwt:      jwt,
+		mail:     mail,
+	}
+}
+
+func (u *InviteUseCaseImpl) AcceptInvite(token, password string) (*entities.User, error) {
+	if password == "" {
+		return nil, errors.New("password is required")
+	}
+
+	parsed, err := jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("unexpected signing method")
+		}
+		return []byte(u.jwt.Secret), nil
+	})
+	if err != nil || !parsed.Valid {
+		return nil, errors.New("invalid or expired invite")
+	}
+
+	claims, ok := parsed.Claims.(jwt.MapClaims)
+	if !ok || claims["purpose"] != "invite" {
+		return nil, errors.New("invalid invite")
+	}
+
+	inviteID, _ := claims["invite_id"].(string)
+	invite, err := u.repo.GetInviteByID(inviteID)
+	if err != nil {
+		return nil, errors.New("invalid invite")
+	}
+
+	switch inviteStatus(invite) {
+	case "accepted":
+		return nil, errors.New("invite has already been accepted")
+	case "revoked":
+		return nil, errors.New("invite has been revoked")
+	case "expired":
+		return nil, errors.New("invite is expired")
+	}
+
+	user, err := u.userRepo.GetUserByID(invite.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Password = string(hashedPassword)
+	user.Status = "active"
+	updatedUser, err := u.userRepo.UpdateUserByID(user)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	invite.AcceptedAt = &now
+	if _, err := u.repo.UpdateInvite(invite); err != nil {
+		return nil, err
+	}
+
+	return updatedUser, nil
+}
+
+func (u *InviteUseCaseImpl) ResendInvite(userID string) (map[string]interface{}, error) {
+	user, err := u.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Status != "pending" {
+		return nil, errors.New("user has already accepted the invite")
+	}
+
+	if _, err := u.repo.RevokeOpenInvitesByUserID(user.ID); err != nil {
+		return nil, err
+	}
+
+	invite, err := issueInvite(u.repo, user, u.jwt, u.mail)
+	if err != nil {
+		return nil, err
+	}
+
+	return inviteData(invite), nil
+}
+
+func (u *InviteUseCaseImpl) RevokeInvite(userID string) error {
+	revoked, err := u.repo.RevokeOpenInvitesByUserID(userID)
+	if err != nil {
+		return err
+	}
+
+	if revoked == 0 {
+		return errors.New("no pending invite found")
+	}
+
+	return nil
+}
+
+func (u *InviteUseCaseImpl) GetInviteByUserID(userID string) (map[string]interface{}, error) {
+	invite, err := u.repo.GetLatestInviteByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return inviteData(invite), nil
+}
+
+func (u *InviteUseCaseImpl) GetAllInvites() ([]map[string]interface{}, error) {
+	invites, err := u.repo.GetAllLatestInvites()
+	if err != nil {
+		return nil, err
+	}
+
+	var invitesList []map[string]interface{}
+	for i := range invites {
+		invitesList = append(invitesList, inviteData(&invites[i]))
+	}
+
+	return invitesList, nil
+}
+
+func issueInvite(repo repositories.InviteRepository, user *entities.User, jwtConfig configs.JWT, mail configs.Mail) (*entities.Invite, error) {
+	invite := &entities.Invite{
+		ID:        uuid.New().String(),
+		UserID:    user.ID,
+		ExpiresAt: time.Now().Add(inviteExpire),
+	}
+
+	createdInvite, err := repo.CreateInvite(invite)
+	if err != nil {
+		return nil, err
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"purpose":   "invite",
+		"invite_id": createdInvite.ID,
+		"user_id":   user.ID,
+		"exp":       createdInvite.ExpiresAt.Unix(),
+	})
+
+	tokenString, err := token.SignedString([]byte(jwtConfig.Secret))
+	if err != nil {
+		return nil, err
+	}
+
+	link := fmt.Sprintf("%s?token=%s", mail.InviteURL, url.QueryEscape(tokenString))
+	if err := utils.SendInviteMail("./assets/Invitemail.html", *user, link, createdInvite.ExpiresAt, mail); err != nil {
+		return nil, err
+	}
+
+	return createdInvite, nil
+}
+
+func inviteStatus(invite *entities.Invite) string {
+	switch {
+	case invite.AcceptedAt != nil:
+		return "accepted"
+	case invite.RevokedAt != nil:
+		return "revoked"
+	case time.Now().After(invite.ExpiresAt):
+		return "expired"
+	default:
+		return "pending"
+	}
+}
+
+func inviteData(invite *entities.Invite) map[string]interface{} {
+	return map[string]interface{}{
+		"id":          invite.ID,
+		"user_id":     invite.UserID,
+		"email":       invite.User.Email,
+		"name":        invite.User.Firstname + " " + invite.User.Lastname,
+		"role":        invite.User.Role.RoleName,
+		"status":      inviteStatus(invite),
+		"expires_at":  invite.ExpiresAt,
+		"accepted_at": invite.AcceptedAt,
+		"revoked_at":  invite.RevokedAt,
+		"created_at":  invite.CreatedAt,
+	}
+}
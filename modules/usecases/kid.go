@@ -19,14 +19,16 @@ type KidUseCase interface {
 }
 
 type KidUseCaseImpl struct {
-	repo repositories.KidsRepository
-	supa configs.Supabase
+	repo      repositories.KidsRepository
+	alertRepo repositories.GrowthAlertRepository
+	supa      configs.Supabase
 }
 
-func NewKidUseCase(repo repositories.KidsRepository, supa configs.Supabase) *KidUseCaseImpl {
+func NewKidUseCase(repo repositories.KidsRepository, alertRepo repositories.GrowthAlertRepository, supa configs.Supabase) *KidUseCaseImpl {
 	return &KidUseCaseImpl{
-		repo: repo,
-		supa: supa,
+		repo:      repo,
+		alertRepo: alertRepo,
+		supa:      supa,
 	}
 }
 
@@ -50,7 +52,7 @@ func (u *KidUseCaseImpl) CreateKid(kid *entities.Kid, image *multipart.FileHeade
 		kid.ImageLink = imageUrl
 	}
 
-	createdKid, err := u.repo.CreateKid(kid)
+	createdKid, err := u.repo.WithContext(ctx.UserContext()).CreateKid(kid)
 	if err != nil {
 		return nil, err
 	}
@@ -74,6 +76,11 @@ func (u *KidUseCaseImpl) GetKidByID(id string) (map[string]interface{}, error) {
 		return nil, err
 	}
 
+	alerts, err := u.alertRepo.GetAlertsByKidID(kid.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	kidData := map[string]interface{}{
 		"id":              kid.ID,
 		"firstname":       kid.Firstname,
@@ -95,6 +102,7 @@ func (u *KidUseCaseImpl) GetKidByID(id string) (map[string]interface{}, error) {
 		"adjusted_days":   ad_days,
 		"adjusted_months": ad_months,
 		"adjusted_years":  ad_years,
+		"alerts":          alerts,
 	}
 
 	return kidData, nil
@@ -116,6 +124,11 @@ func (u *KidUseCaseImpl) GetKidByIDForUser(id string) (map[string]interface{}, e
 		return nil, err
 	}
 
+	alerts, err := u.alertRepo.GetAlertsByKidID(kid.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	kidData := map[string]interface{}{
 		"id":              kid.ID,
 		"firstname":       kid.Firstname,
@@ -136,6 +149,7 @@ func (u *KidUseCaseImpl) GetKidByIDForUser(id string) (map[string]interface{}, e
 		"adjusted_days":   ad_days,
 		"adjusted_months": ad_months,
 		"adjusted_years":  ad_years,
+		"alerts":          alerts,
 	}
 
 	return kidData, nil
@@ -181,7 +195,7 @@ func (u *KidUseCaseImpl) UpdateKidByID(id string, kid *entities.Kid, image *mult
 	existingKid.RHType = kid.RHType
 	existingKid.Note = kid.Note
 	existingKid.Sex = kid.Sex
-	updatedKid, err := u.repo.UpdateKidByID(existingKid)
+	updatedKid, err := u.repo.WithContext(ctx.UserContext()).UpdateKidByID(existingKid)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,132 @@
+package usecases
+
+import (
+	"Beside-Mom-BE/modules/entities"
+	"Beside-Mom-BE/modules/repositories"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PeriodUseCase interface {
+	GetAllPeriods(includeRetired bool) ([]entities.Period, error)
+	CreatePeriod(period *entities.Period, ctx *fiber.Ctx) (*entities.Period, error)
+	UpdatePeriod(id int, period *entities.Period, ctx *fiber.Ctx) (*entities.Period, error)
+	ReorderPeriods(ids []int) ([]entities.Period, error)
+	RetirePeriod(id int) error
+}
+
+type PeriodUseCaseImpl struct {
+	repo repositories.PeriodRepository
+}
+
+func NewPeriodUseCase(repo repositories.PeriodRepository) *PeriodUseCaseImpl {
+	return &PeriodUseCaseImpl{repo: repo}
+}
+
+func (u *PeriodUseCaseImpl) GetAllPeriods(includeRetired bool) ([]entities.Period, error) {
+	return u.repo.GetAllPeriods(includeRetired)
+}
+
+func (u *PeriodUseCaseImpl) CreatePeriod(period *entities.Period, ctx *fiber.Ctx) (*entities.Period, error) {
+	if err := validatePeriod(period); err != nil {
+		return nil, err
+	}
+
+	return u.repo.WithContext(ctx.UserContext()).CreatePeriod(&entities.Period{
+		Period:      period.Period,
+		StartMonths: period.StartMonths,
+		EndMonths:   period.EndMonths,
+	})
+}
+
+func (u *PeriodUseCaseImpl) UpdatePeriod(id int, period *entities.Period, ctx *fiber.Ctx) (*entities.Period, error) {
+	existing, err := u.repo.GetPeriodByID(id)
+	if err != nil {
+		return nil, errors.New("period not found")
+	}
+
+	if err := validatePeriod(period); err != nil {
+		return nil, err
+	}
+
+	existing.Period = period.Period
+	existing.StartMonths = period.StartMonths
+	existing.EndMonths = period.EndMonths
+	return u.repo.WithContext(ctx.UserContext()).UpdatePeriod(existing)
+}
+
+func (u *PeriodUseCaseImpl) ReorderPeriods(ids []int) ([]entities.Period, error) {
+	periods, err := u.repo.GetAllPeriods(true)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make([]int, 0, len(periods))
+	for _, p := range periods {
+		existing = append(existing, p.ID)
+	}
+
+	if err := validateOrder(ids, existing); err != nil {
+		return nil, err
+	}
+
+	if err := u.repo.ReorderPeriods(ids); err != nil {
+		return nil, err
+	}
+
+	return u.repo.GetAllPeriods(false)
+}
+
+func (u *PeriodUseCaseImpl) RetirePeriod(id int) error {
+	period, err := u.repo.GetPeriodByID(id)
+	if err != nil {
+		return errors.New("period not found")
+	}
+
+	if period.RetiredAt != nil {
+		return errors.New("period is already retired")
+	}
+
+	return u.repo.RetirePeriod(id)
+}
+
+func validatePeriod(period *entities.Period) error {
+	period.Period = strings.TrimSpace(period.Period)
+	if period.Period == "" {
+		return errors.New("period name is required")
+	}
+
+	if period.StartMonths < 0 || period.EndMonths <= period.StartMonths {
+		return errors.New("end_months must be greater than start_months and start_months must not be negative")
+	}
+
+	return nil
+}
+
+func validateOrder(ids []int, existing []int) error {
+	if len(ids) == 0 {
+		return errors.New("ids must not be empty")
+	}
+
+	known := make(map[int]bool, len(existing))
+	for _, id := range existing {
+		known[id] = true
+	}
+
+	seen := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		if !known[id] {
+			return fmt.Errorf("id %d does not exist", id)
+		}
+
+		if seen[id] {
+			return fmt.Errorf("id %d is listed more than once", id)
+		}
+		seen[id] = true
+	}
+
+	return nil
+}
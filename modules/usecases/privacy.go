@@ -0,0 +1,329 @@
+package usecases
+
+import (
+	"Beside-Mom-BE/configs"
+	"Beside-Mom-BE/modules/entities"
+	"Beside-Mom-BE/modules/repositories"
+	"Beside-Mom-BE/pkg/database"
+	"Beside-Mom-BE/pkg/utils"
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"path"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type PrivacyUseCase interface {
+	ExportUserData(userID string) ([]byte, error)
+	EraseUser(userID string, ctx *fiber.Ctx) error
+	EraseOwnAccount(userID string, password string, ctx *fiber.Ctx) error
+}
+
+type PrivacyUseCaseImpl struct {
+	repo     repositories.PrivacyRepository
+	userRepo repositories.UserRepository
+	supa     configs.Supabase
+}
+
+func NewPrivacyUseCase(repo repositories.PrivacyRepository, userRepo repositories.UserRepository, supa configs.Supabase) *PrivacyUseCaseImpl {
+	return &PrivacyUseCaseImpl{
+		repo:     repo,
+		userRepo: userRepo,
+		supa:     supa,
+	}
+}
+
+type exportTable struct {
+	name    string
+	columns []string
+	rows    [][]interface{}
+}
+
+func (u *PrivacyUseCaseImpl) ExportUserData(userID string) ([]byte, error) {
+	data, err := u.repo.GetUserExport(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	for _, table := range buildExportTables(data) {
+		if err := writeExportTable(zw, table); err != nil {
+			return nil, err
+		}
+	}
+
+	images := map[string]string{}
+	if data.User.ImageLink != "" {
+		images["images/profile"+path.Ext(data.User.ImageLink)] = data.User.ImageLink
+	}
+
+	for _, kid := range data.Kids {
+		if kid.ImageLink != "" {
+			images["images/kid-"+kid.ID+path.Ext(kid.ImageLink)] = kid.ImageLink
+		}
+	}
+
+	for _, h := range data.Histories {
+		if h.MediaLink != "" {
+			images["media/history-"+h.ID+path.Ext(h.MediaLink)] = h.MediaLink
+		}
+	}
+
+	var missing []string
+	for name, link := range images {
+		content, err := utils.DownloadImage(link, u.supa)
+		if err != nil {
+			missing = append(missing, link)
+			continue
+		}
+
+		w, err := zw.Create(name)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := w.Write(content); err != nil {
+			return nil, err
+		}
+	}
+
+	manifest, err := json.MarshalIndent(map[string]interface{}{
+		"user_id":        data.User.ID,
+		"exported_at":    time.Now(),
+		"missing_images": missing,
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := zw.Create("manifest.json")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := w.Write(manifest); err != nil {
+		return nil, err
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (u *PrivacyUseCaseImpl) EraseUser(userID string, ctx *fiber.Ctx) error {
+	data, err := u.repo.GetUserExport(userID)
+	if err != nil {
+		return err
+	}
+
+	if data.User.Role.RoleName != "User" {
+		return errors.New("only mom accounts can be erased")
+	}
+
+	links := []string{data.User.ImageLink}
+	for _, kid := range data.Kids {
+		links = append(links, kid.ImageLink)
+	}
+
+	for _, h := range data.Histories {
+		links = append(links, h.MediaLink)
+	}
+
+	if err := u.repo.WithContext(database.WithRedactedAudit(ctx.UserContext())).EraseUser(userID); err != nil {
+		return err
+	}
+
+	for _, link := range links {
+		if link == "" {
+			continue
+		}
+
+		if err := utils.DeleteImage(link, u.supa); err != nil {
+			log.Printf("Failed to delete erased media %s: %v", link, err)
+		}
+	}
+
+	return nil
+}
+
+func (u *PrivacyUseCaseImpl) EraseOwnAccount(userID string, password string, ctx *fiber.Ctx) error {
+	user, err := u.userRepo.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+		return errors.New("invalid password")
+	}
+
+	return u.EraseUser(userID, ctx)
+}
+
+func buildExportTables(data *entities.UserExport) []exportTable {
+	profile := exportTable{
+		name:    "profile",
+		columns: []string{"id", "pid", "firstname", "lastname", "email", "role", "status", "image_link", "created_at", "updated_at"},
+		rows: [][]interface{}{{
+			data.User.ID, data.User.PID, data.User.Firstname, data.User.Lastname, data.User.Email,
+			data.User.Role.RoleName, data.User.Status, data.User.ImageLink, data.User.CreatedAt, data.User.UpdatedAt,
+		}},
+	}
+
+	kids := exportTable{
+		name: "kids",
+		columns: []string{"id", "firstname", "lastname", "username", "sex", "birth_date", "before_birth", "blood_type",
+			"rh_type", "birth_weight", "birth_length", "note", "image_link", "created_at", "updated_at"},
+	}
+	for _, k := range data.Kids {
+		kids.rows = append(kids.rows, []interface{}{
+			k.ID, k.Firstname, k.Lastname, k.Username, k.Sex, k.BirthDate.Format("2006-01-02"), k.BeforeBirth, k.BloodType,
+			k.RHType, k.BirthWeight, k.BirthLength, k.Note, k.ImageLink, k.CreatedAt, k.UpdatedAt,
+		})
+	}
+
+	growths := exportTable{
+		name:    "growths",
+		columns: []string{"id", "kid_id", "months", "corrected_months", "length", "weight", "head_circumference", "created_at", "updated_at"},
+	}
+	for _, g := range data.Growths {
+		growths.rows = append(growths.rows, []interface{}{g.ID, g.KidID, g.Months, g.CorrectedMonths, g.Length, g.Weight, g.HeadCircumference, g.CreatedAt, g.UpdatedAt})
+	}
+
+	evaluates := exportTable{
+		name:    "evaluates",
+		columns: []string{"id", "kid_id", "period_id", "evaluated_times", "status", "result", "due_from", "due_to", "completed_at", "created_at"},
+	}
+	for _, e := range data.Evaluates {
+		evaluates.rows = append(evaluates.rows, []interface{}{e.ID, e.KidID, e.PeriodID, e.EvaluatedTimes, e.Status, e.Result, e.DueFrom, e.DueTo, e.CompletedAt, e.CreatedAt})
+	}
+
+	histories := exportTable{
+		name:    "histories",
+		columns: []string{"id", "kid_id", "quiz_id", "quiz_version", "question", "evaluated_times", "times", "answer", "status", "note", "media_link", "media_type", "created_at"},
+	}
+	for _, h := range data.Histories {
+		histories.rows = append(histories.rows, []interface{}{h.ID, h.KidID, h.QuizID, h.Quiz.Version, h.Quiz.Question, h.EvaluatedTimes, h.Times, h.Answer, h.Status, h.Note, h.MediaLink, h.MediaType, h.CreatedAt})
+	}
+
+	appointments := exportTable{
+		name:    "appointments",
+		columns: []string{"id", "title", "date", "start_time", "building", "doctor", "requirement", "status", "created_at"},
+	}
+	for _, a := range data.Appointments {
+		appointments.rows = append(appointments.rows, []interface{}{a.ID, a.Title, a.Date, a.StartTime, a.Building, a.Doctor, a.Requirement, a.Status, a.CreatedAt})
+	}
+
+	likes := exportTable{
+		name:    "likes",
+		columns: []string{"video_id", "video_title", "created_at"},
+	}
+	for _, l := range data.Likes {
+		likes.rows = append(likes.rows, []interface{}{l.VideoID, l.Video.Title, l.CreatedAt})
+	}
+
+	caregivers := exportTable{
+		name:    "caregivers",
+		columns: []string{"id", "kid_id", "user_id", "access", "relation", "invited_by", "revoked_at", "created_at"},
+	}
+	for _, c := range data.Caregivers {
+		caregivers.rows = append(caregivers.rows, []interface{}{c.ID, c.KidID, c.UserID, c.Access, c.Relation, c.InvitedBy, c.RevokedAt, c.CreatedAt})
+	}
+
+	referrals := exportTable{
+		name:    "referrals",
+		columns: []string{"id", "kid_id", "evaluated_times", "category_id", "state", "retest_due_at", "retest_passed", "appointment_id", "note", "closed_at", "created_at"},
+	}
+	for _, r := range data.Referrals {
+		referrals.rows = append(referrals.rows, []interface{}{r.ID, r.KidID, r.EvaluatedTimes, r.CategoryID, r.State, r.RetestDueAt.Format("2006-01-02"), r.RetestPassed, r.AppointmentID, r.Note, r.ClosedAt, r.CreatedAt})
+	}
+
+	return []exportTable{profile, kids, growths, evaluates, histories, appointments, likes, caregivers, referrals}
+}
+
+func writeExportTable(zw *zip.Writer, table exportTable) error {
+	records := make([]map[string]interface{}, 0, len(table.rows))
+	for _, row := range table.rows {
+		record := make(map[string]interface{}, len(table.columns))
+		for i, column := range table.columns {
+			record[column] = row[i]
+		}
+		records = append(records, record)
+	}
+
+	content, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	w, err := zw.Create(table.name + ".json")
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write(content); err != nil {
+		return err
+	}
+
+	w, err = zw.Create(table.name + ".csv")
+	if err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(table.columns); err != nil {
+		return err
+	}
+
+	for _, row := range table.rows {
+		record := make([]string, len(row))
+		for i, value := range row {
+			record[i] = exportValue(value)
+		}
+
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func exportValue(value interface{}) string {
+	switch v := value.(type) {
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return v.Format(time.RFC3339)
+	case *float64:
+		if v == nil {
+			return ""
+		}
+		return fmt.Sprint(*v)
+	case *bool:
+		if v == nil {
+			return ""
+		}
+		return fmt.Sprint(*v)
+	case *string:
+		if v == nil {
+			return ""
+		}
+		return *v
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -5,32 +5,63 @@ import (
 	"Beside-Mom-BE/modules/entities"
 	"Beside-Mom-BE/modules/repositories"
 	"Beside-Mom-BE/pkg/utils"
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"mime/multipart"
+	"net/http"
 	"os"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
+var quizManifestColumns = []string{"external_key", "question", "desc", "solution", "suggestion", "category_id", "period_id", "banner"}
+
+var quizKeyPattern = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+var generatedQuizKeyPattern = regexp.MustCompile(`^quiz-[0-9]+$`)
+
+const quizBannerMaxBytes = 10 << 20
+
 type QuizUseCase interface {
 	CreateQuiz(quiz *entities.Quiz, banner *multipart.FileHeader, ctx *fiber.Ctx) (*entities.Quiz, error)
 	GetQuizByID(id int) (*entities.Quiz, error)
-	GetAllQuiz() ([]entities.Quiz, error)
+	GetAllQuiz(includeRetired bool) ([]entities.Quiz, error)
 	GetQuizByIDandPeriod(id int, period int, cate int) (*entities.Quiz, error)
 	GetQuizByCategoryandPeriod(period int, cate int) ([]entities.Quiz, error)
 	UpdateQuizByID(id int, quiz *entities.Quiz, banner *multipart.FileHeader, ctx *fiber.Ctx) (*entities.Quiz, error)
-	DeleteQuizByID(id int) error
+	RetireQuizByID(id int) error
+	GetQuizVersions(id int) ([]entities.QuizVersion, error)
+	GetQuizVersion(id int, version int) (*entities.QuizVersion, error)
+	ImportQuizzes(archive io.ReaderAt, size int64, ctx *fiber.Ctx) ([]entities.QuizImportResult, error)
+	ExportQuizzes(format string) ([]byte, error)
 }
 
 type QuizUseCaseImpl struct {
-	repo repositories.QuizRepository
-	supa configs.Supabase
+	repo         repositories.QuizRepository
+	categoryRepo repositories.CategoryRepository
+	periodRepo   repositories.PeriodRepository
+	sync         HistorySyncUseCase
+	supa         configs.Supabase
 }
 
-func NewQuizUseCase(repo repositories.QuizRepository, supa configs.Supabase) *QuizUseCaseImpl {
+func NewQuizUseCase(repo repositories.QuizRepository, categoryRepo repositories.CategoryRepository, periodRepo repositories.PeriodRepository, sync HistorySyncUseCase, supa configs.Supabase) *QuizUseCaseImpl {
 	return &QuizUseCaseImpl{
-		repo: repo,
-		supa: supa,
+		repo:         repo,
+		categoryRepo: categoryRepo,
+		periodRepo:   periodRepo,
+		sync:         sync,
+		supa:         supa,
 	}
 }
 
@@ -41,6 +72,12 @@ func (u *QuizUseCaseImpl) CreateQuiz(quiz *entities.Quiz, banner *multipart.File
 			return nil, err
 		}
 
+		data, err := os.ReadFile("./uploads/" + fileName)
+		if err != nil {
+			os.Remove("./uploads/" + fileName)
+			return nil, err
+		}
+
 		imageUrl, err := utils.UploadImage(fileName, "", u.supa)
 		if err != nil {
 			os.Remove("./uploads/" + fileName)
@@ -52,17 +89,25 @@ func (u *QuizUseCaseImpl) CreateQuiz(quiz *entities.Quiz, banner *multipart.File
 		}
 
 		quiz.Banner = imageUrl
+		quiz.BannerHash = utils.HashBytes(data)
 	}
 
-	return u.repo.CreateQuiz(quiz)
+	userID, _ := ctx.Locals("user_id").(string)
+	createdQuiz, err := u.repo.WithContext(ctx.UserContext()).CreateQuiz(quiz, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	u.sync.Trigger()
+	return createdQuiz, nil
 }
 
 func (u *QuizUseCaseImpl) GetQuizByID(id int) (*entities.Quiz, error) {
 	return u.repo.GetQuizByID(id)
 }
 
-func (u *QuizUseCaseImpl) GetAllQuiz() ([]entities.Quiz, error) {
-	return u.repo.GetAllQuiz()
+func (u *QuizUseCaseImpl) GetAllQuiz(includeRetired bool) ([]entities.Quiz, error) {
+	return u.repo.GetAllQuiz(includeRetired)
 }
 
 func (u *QuizUseCaseImpl) UpdateQuizByID(id int, quiz *entities.Quiz, banner *multipart.FileHeader, ctx *fiber.Ctx) (*entities.Quiz, error) {
@@ -83,42 +128,52 @@ func (u *QuizUseCaseImpl) UpdateQuizByID(id int, quiz *entities.Quiz, banner *mu
 			return nil, err
 		}
 
-		imageUrl, err := utils.UploadImage(fileName, "", u.supa)
+		data, err := os.ReadFile("./uploads/" + fileName)
 		if err != nil {
 			os.Remove("./uploads/" + fileName)
 			return nil, err
 		}
 
-		if err := os.Remove("./uploads/" + fileName); err != nil {
+		imageUrl, err := utils.UploadImage(fileName, "", u.supa)
+		if err != nil {
+			os.Remove("./uploads/" + fileName)
 			return nil, err
 		}
 
-		if err := utils.DeleteImage(existingQuiz.Banner, u.supa); err != nil {
+		if err := os.Remove("./uploads/" + fileName); err != nil {
 			return nil, err
 		}
 
 		existingQuiz.Banner = imageUrl
+		existingQuiz.BannerHash = utils.HashBytes(data)
 	}
 
-	updatedQuiz, err := u.repo.UpdateQuizByID(existingQuiz)
+	userID, _ := ctx.Locals("user_id").(string)
+	updatedQuiz, err := u.repo.WithContext(ctx.UserContext()).UpdateQuizByID(existingQuiz, userID)
 	if err != nil {
 		return nil, err
 	}
 
+	u.sync.Trigger()
 	return updatedQuiz, nil
 }
 
-func (u *QuizUseCaseImpl) DeleteQuizByID(id int) error {
+func (u *QuizUseCaseImpl) RetireQuizByID(id int) error {
 	existingQuiz, err := u.repo.GetQuizByID(id)
 	if err != nil {
-		return err
+		return errors.New("quiz not found")
 	}
 
-	if err := utils.DeleteImage(existingQuiz.Banner, u.supa); err != nil {
+	if existingQuiz.RetiredAt != nil {
+		return errors.New("quiz is already retired")
+	}
+
+	if err := u.repo.RetireQuizByID(id); err != nil {
 		return err
 	}
 
-	return u.repo.DeleteQuizByID(id)
+	u.sync.Trigger()
+	return nil
 }
 
 func (u *QuizUseCaseImpl) GetQuizByIDandPeriod(id int, period int, cate int) (*entities.Quiz, error) {
@@ -128,3 +183,457 @@ func (u *QuizUseCaseImpl) GetQuizByIDandPeriod(id int, period int, cate int) (*e
 func (u *QuizUseCaseImpl) GetQuizByCategoryandPeriod(period int, cate int) ([]entities.Quiz, error) {
 	return u.repo.GetQuizByCategoryandPeriod(period, cate)
 }
+
+func (u *QuizUseCaseImpl) GetQuizVersions(id int) ([]entities.QuizVersion, error) {
+	return u.repo.GetQuizVersions(id)
+}
+
+func (u *QuizUseCaseImpl) GetQuizVersion(id int, version int) (*entities.QuizVersion, error) {
+	return u.repo.GetQuizVersion(id, version)
+}
+
+func (u *QuizUseCaseImpl) ImportQuizzes(archive io.ReaderAt, size int64, ctx *fiber.Ctx) ([]entities.QuizImportResult, error) {
+	reader, err := zip.NewReader(archive, size)
+	if err != nil {
+		return nil, errors.New("file must be a ZIP archive")
+	}
+
+	var manifest *zip.File
+	files := make(map[string]*zip.File, len(reader.File))
+	for _, f := range reader.File {
+		name := path.Clean(strings.ReplaceAll(f.Name, "\\", "/"))
+		files[name] = f
+		switch path.Base(name) {
+		case "quizzes.json", "quizzes.csv":
+			if manifest != nil {
+				return nil, errors.New("ZIP must contain only one quizzes.json or quizzes.csv manifest")
+			}
+			manifest = f
+		}
+	}
+
+	if manifest == nil {
+		return nil, errors.New("ZIP is missing the quizzes.json or quizzes.csv manifest")
+	}
+
+	items, rows, err := readQuizManifest(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, _ := ctx.Locals("user_id").(string)
+	baseDir := path.Dir(path.Clean(strings.ReplaceAll(manifest.Name, "\\", "/")))
+	categories := make(map[int]error)
+	periods := make(map[int]error)
+	seen := make(map[string]int)
+	changed := false
+
+	results := []entities.QuizImportResult{}
+	for i, item := range items {
+		result := entities.QuizImportResult{Row: rows[i], ExternalKey: item.ExternalKey}
+		if row, ok := seen[item.ExternalKey]; ok && item.ExternalKey != "" {
+			result.Status = "error"
+			result.Message = fmt.Sprintf("external_key is duplicated on row %d", row)
+			results = append(results, result)
+			continue
+		}
+		seen[item.ExternalKey] = rows[i]
+
+		if err := u.validateQuizManifestItem(item, categories, periods); err != nil {
+			result.Status = "error"
+			result.Message = err.Error()
+			results = append(results, result)
+			continue
+		}
+
+		var banner []byte
+		if item.Banner != "" {
+			banner, err = readQuizBanner(files, path.Join(baseDir, item.Banner))
+			if err != nil {
+				result.Status = "error"
+				result.Message = err.Error()
+				results = append(results, result)
+				continue
+			}
+		}
+
+		saved, status, err := u.importQuiz(item, banner, userID, ctx)
+		if err != nil {
+			result.Status = "error"
+			result.Message = err.Error()
+		} else {
+			result.Status = status
+			result.QuizID = saved.ID
+			result.Version = saved.Version
+			changed = changed || status != "unchanged"
+		}
+
+		results = append(results, result)
+	}
+
+	if changed {
+		u.sync.Trigger()
+	}
+
+	return results, nil
+}
+
+func (u *QuizUseCaseImpl) importQuiz(item entities.QuizManifestItem, banner []byte, userID string, ctx *fiber.Ctx) (*entities.Quiz, string, error) {
+	repo := u.repo.WithContext(ctx.UserContext())
+	existingQuiz, err := u.repo.GetQuizByExternalKey(item.ExternalKey)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, "", err
+	}
+
+	if existingQuiz == nil && banner == nil {
+		return nil, "", errors.New("banner is required for a new quiz")
+	}
+
+	if existingQuiz == nil && generatedQuizKeyPattern.MatchString(item.ExternalKey) {
+		return nil, "", errors.New("external_key must not use the reserved quiz-<number> format")
+	}
+
+	uploaded := ""
+	bannerHash := utils.HashBytes(banner)
+	if banner != nil && (existingQuiz == nil || existingQuiz.BannerHash != bannerHash) {
+		uploaded, err = u.uploadQuizBanner(banner)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	if existingQuiz == nil {
+		key := item.ExternalKey
+		quiz := &entities.Quiz{
+			ExternalKey: &key,
+			Question:    item.Question,
+			Description: item.Description,
+			Solution:    item.Solution,
+			Suggestion:  item.Suggestion,
+			Banner:      uploaded,
+			BannerHash:  bannerHash,
+			CategoryID:  item.CategoryID,
+			PeriodID:    item.PeriodID,
+		}
+
+		createdQuiz, err := repo.CreateQuiz(quiz, userID)
+		if err != nil {
+			utils.DeleteImage(uploaded, u.supa)
+			return nil, "", err
+		}
+
+		return createdQuiz, "created", nil
+	}
+
+	previousVersion := existingQuiz.Version
+	existingQuiz.Question = item.Question
+	existingQuiz.Description = item.Description
+	existingQuiz.Solution = item.Solution
+	existingQuiz.Suggestion = item.Suggestion
+	existingQuiz.CategoryID = item.CategoryID
+	existingQuiz.PeriodID = item.PeriodID
+	if uploaded != "" {
+		existingQuiz.Banner = uploaded
+		existingQuiz.BannerHash = bannerHash
+	}
+
+	updatedQuiz, err := repo.UpdateQuizByID(existingQuiz, userID)
+	if err != nil {
+		if uploaded != "" {
+			utils.DeleteImage(uploaded, u.supa)
+		}
+		return nil, "", err
+	}
+
+	if updatedQuiz.Version == previousVersion {
+		return updatedQuiz, "unchanged", nil
+	}
+
+	return updatedQuiz, "updated", nil
+}
+
+func (u *QuizUseCaseImpl) validateQuizManifestItem(item entities.QuizManifestItem, categories map[int]error, periods map[int]error) error {
+	if item.ExternalKey == "" {
+		return errors.New("external_key is required")
+	}
+
+	if item.Question == "" {
+		return errors.New("question is required")
+	}
+
+	categoryErr, ok := categories[item.CategoryID]
+	if !ok {
+		category, err := u.categoryRepo.GetCategoryByID(item.CategoryID)
+		if err != nil {
+			categoryErr = fmt.Errorf("category %d not found", item.CategoryID)
+		} else if category.RetiredAt != nil {
+			categoryErr = fmt.Errorf("category %d is retired", item.CategoryID)
+		}
+		categories[item.CategoryID] = categoryErr
+	}
+
+	if categoryErr != nil {
+		return categoryErr
+	}
+
+	periodErr, ok := periods[item.PeriodID]
+	if !ok {
+		period, err := u.periodRepo.GetPeriodByID(item.PeriodID)
+		if err != nil {
+			periodErr = fmt.Errorf("period %d not found", item.PeriodID)
+		} else if period.RetiredAt != nil {
+			periodErr = fmt.Errorf("period %d is retired", item.PeriodID)
+		}
+		periods[item.PeriodID] = periodErr
+	}
+
+	return periodErr
+}
+
+func (u *QuizUseCaseImpl) uploadQuizBanner(banner []byte) (string, error) {
+	fileName := uuid.New().String() + "_title.jpg"
+	if err := os.WriteFile("./uploads/"+fileName, banner, 0644); err != nil {
+		return "", err
+	}
+
+	imageUrl, err := utils.UploadImage(fileName, "", u.supa)
+	if err != nil {
+		os.Remove("./uploads/" + fileName)
+		return "", err
+	}
+
+	if err := os.Remove("./uploads/" + fileName); err != nil {
+		return "", err
+	}
+
+	return imageUrl, nil
+}
+
+func readQuizBanner(files map[string]*zip.File, name string) ([]byte, error) {
+	f, ok := files[name]
+	if !ok {
+		return nil, fmt.Errorf("banner %s not found in ZIP", name)
+	}
+
+	if f.UncompressedSize64 > quizBannerMaxBytes {
+		return nil, fmt.Errorf("banner %s must be %d MB or smaller", name, quizBannerMaxBytes>>20)
+	}
+
+	file, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, quizBannerMaxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > quizBannerMaxBytes {
+		return nil, fmt.Errorf("banner %s must be %d MB or smaller", name, quizBannerMaxBytes>>20)
+	}
+
+	if !strings.HasPrefix(http.DetectContentType(data), "image/") {
+		return nil, fmt.Errorf("banner %s is not an image", name)
+	}
+
+	return data, nil
+}
+
+func readQuizManifest(manifest *zip.File) ([]entities.QuizManifestItem, []int, error) {
+	file, err := manifest.Open()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer file.Close()
+
+	if strings.HasSuffix(manifest.Name, ".json") {
+		var items []entities.QuizManifestItem
+		if err := json.NewDecoder(file).Decode(&items); err != nil {
+			return nil, nil, fmt.Errorf("invalid quizzes.json: %w", err)
+		}
+
+		rows := make([]int, len(items))
+		for i := range items {
+			items[i] = trimQuizManifestItem(items[i])
+			rows[i] = i + 1
+		}
+
+		return items, rows, nil
+	}
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, errors.New("quizzes.csv is empty or unreadable")
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+
+	for _, name := range quizManifestColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, nil, fmt.Errorf("quizzes.csv is missing the %s column", name)
+		}
+	}
+
+	items := []entities.QuizManifestItem{}
+	rows := []int{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid quizzes.csv: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		field := func(name string) string {
+			i := columns[name]
+			if i >= len(record) {
+				return ""
+			}
+			return record[i]
+		}
+
+		item := entities.QuizManifestItem{
+			ExternalKey: field("external_key"),
+			Question:    field("question"),
+			Description: field("desc"),
+			Solution:    field("solution"),
+			Suggestion:  field("suggestion"),
+			Banner:      field("banner"),
+		}
+		item.CategoryID, _ = strconv.Atoi(strings.TrimSpace(field("category_id")))
+		item.PeriodID, _ = strconv.Atoi(strings.TrimSpace(field("period_id")))
+
+		items = append(items, trimQuizManifestItem(item))
+		rows = append(rows, line)
+	}
+
+	return items, rows, nil
+}
+
+func trimQuizManifestItem(item entities.QuizManifestItem) entities.QuizManifestItem {
+	item.ExternalKey = strings.TrimSpace(item.ExternalKey)
+	item.Question = strings.TrimSpace(item.Question)
+	item.Description = strings.TrimSpace(item.Description)
+	item.Solution = strings.TrimSpace(item.Solution)
+	item.Suggestion = strings.TrimSpace(item.Suggestion)
+	item.Banner = strings.TrimSpace(item.Banner)
+	return item
+}
+
+func (u *QuizUseCaseImpl) ExportQuizzes(format string) ([]byte, error) {
+	if format != "json" && format != "csv" {
+		return nil, errors.New("format must be json or csv")
+	}
+
+	quizzes, err := u.repo.GetAllQuiz(false)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := new(bytes.Buffer)
+	archive := zip.NewWriter(buf)
+	items := make([]entities.QuizManifestItem, 0, len(quizzes))
+	for _, quiz := range quizzes {
+		key := "quiz-" + strconv.Itoa(quiz.ID)
+		if quiz.ExternalKey != nil && *quiz.ExternalKey != "" {
+			key = *quiz.ExternalKey
+		}
+
+		item := entities.QuizManifestItem{
+			ExternalKey: key,
+			Question:    quiz.Question,
+			Description: quiz.Description,
+			Solution:    quiz.Solution,
+			Suggestion:  quiz.Suggestion,
+			CategoryID:  quiz.CategoryID,
+			PeriodID:    quiz.PeriodID,
+		}
+
+		data, err := utils.DownloadImage(quiz.Banner, u.supa)
+		if err != nil {
+			return nil, fmt.Errorf("failed to download banner of quiz %d: %w", quiz.ID, err)
+		}
+
+		ext := path.Ext(quiz.Banner)
+		if ext == "" || len(ext) > 5 {
+			ext = ".jpg"
+		}
+
+		name := "banners/" + strconv.Itoa(quiz.ID) + "_" + quizKeyPattern.ReplaceAllString(key, "_") + ext
+		w, err := archive.Create(name)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := w.Write(data); err != nil {
+			return nil, err
+		}
+		item.Banner = name
+
+		items = append(items, item)
+	}
+
+	manifest, err := writeQuizManifest(items, format)
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := archive.Create("quizzes." + format)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := w.Write(manifest); err != nil {
+		return nil, err
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func writeQuizManifest(items []entities.QuizManifestItem, format string) ([]byte, error) {
+	if format == "json" {
+		return json.MarshalIndent(items, "", "  ")
+	}
+
+	buf := new(bytes.Buffer)
+	w := csv.NewWriter(buf)
+	if err := w.Write(quizManifestColumns); err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		record := []string{
+			item.ExternalKey,
+			item.Question,
+			item.Description,
+			item.Solution,
+			item.Suggestion,
+			strconv.Itoa(item.CategoryID),
+			strconv.Itoa(item.PeriodID),
+			item.Banner,
+		}
+
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
@@ -0,0 +1,125 @@
+package usecases
+
+import (
+	"Beside-Mom-BE/modules/entities"
+	"Beside-Mom-BE/modules/repositories"
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ReferralUseCase interface {
+	GetReferrals(state string, assigneeID string) ([]entities.Referral, error)
+	GetReferralByID(id string) (*entities.Referral, error)
+	UpdateReferral(id string, state string, assigneeID string, appointmentID string, retestDueAt *time.Time, note *string, ctx *fiber.Ctx) (*entities.Referral, error)
+}
+
+type ReferralUseCaseImpl struct {
+	repo     repositories.ReferralRepository
+	userRepo repositories.UserRepository
+	kidRepo  repositories.KidsRepository
+	appRepo  repositories.AppRepository
+}
+
+func NewReferralUseCase(repo repositories.ReferralRepository, userRepo repositories.UserRepository, kidRepo repositories.KidsRepository, appRepo repositories.AppRepository) *ReferralUseCaseImpl {
+	return &ReferralUseCaseImpl{
+		repo:     repo,
+		userRepo: userRepo,
+		kidRepo:  kidRepo,
+		appRepo:  appRepo,
+	}
+}
+
+var referralTransitions = map[string][]string{
+	entities.ReferralStateOpen:     {entities.ReferralStateRetested, entities.ReferralStateReferred, entities.ReferralStateClosed},
+	entities.ReferralStateRetested: {entities.ReferralStateOpen, entities.ReferralStateReferred, entities.ReferralStateClosed},
+	entities.ReferralStateReferred: {entities.ReferralStateClosed},
+	entities.ReferralStateClosed:   {},
+}
+
+func (u *ReferralUseCaseImpl) GetReferrals(state string, assigneeID string) ([]entities.Referral, error) {
+	if _, ok := referralTransitions[state]; state != "" && !ok {
+		return nil, errors.New("state must be open, retested, referred or closed")
+	}
+
+	return u.repo.GetReferrals(state, assigneeID)
+}
+
+func (u *ReferralUseCaseImpl) GetReferralByID(id string) (*entities.Referral, error) {
+	return u.repo.GetReferralByID(id)
+}
+
+func (u *ReferralUseCaseImpl) UpdateReferral(id string, state string, assigneeID string, appointmentID string, retestDueAt *time.Time, note *string, ctx *fiber.Ctx) (*entities.Referral, error) {
+	referral, err := u.repo.GetReferralByID(id)
+	if err != nil {
+		return nil, errors.New("referral not found")
+	}
+
+	if referral.State == entities.ReferralStateClosed {
+		return nil, errors.New("referral is already closed")
+	}
+
+	if state != "" && state != referral.State {
+		if !referralCanMove(referral.State, state) {
+			return nil, errors.New("referral cannot move from " + referral.State + " to " + state)
+		}
+
+		referral.State = state
+		if state == entities.ReferralStateClosed {
+			now := time.Now()
+			referral.ClosedAt = &now
+		}
+	}
+
+	if assigneeID != "" {
+		assignee, err := u.userRepo.GetUserByID(assigneeID)
+		if err != nil {
+			return nil, errors.New("assignee not found")
+		}
+
+		if assignee.Role.RoleName != "Nurse" {
+			return nil, errors.New("referrals can only be assigned to a nurse")
+		}
+
+		referral.AssigneeID = &assignee.ID
+	}
+
+	if appointmentID != "" {
+		app, err := u.appRepo.GetAppByID(appointmentID)
+		if err != nil {
+			return nil, errors.New("appointment not found")
+		}
+
+		ownerID, err := u.kidRepo.GetKidOwnerID(referral.KidID)
+		if err != nil {
+			return nil, err
+		}
+
+		if app.UserID != ownerID {
+			return nil, errors.New("appointment does not belong to the kid's mom")
+		}
+
+		referral.AppointmentID = &app.ID
+	}
+
+	if retestDueAt != nil {
+		referral.RetestDueAt = *retestDueAt
+	}
+
+	if note != nil {
+		referral.Note = *note
+	}
+
+	return u.repo.WithContext(ctx.UserContext()).UpdateReferral(referral)
+}
+
+func referralCanMove(from string, to string) bool {
+	for _, next := range referralTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+
+	return false
+}
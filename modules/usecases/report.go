@@ -0,0 +1,167 @@
+package usecases
+
+import (
+	"Beside-Mom-BE/configs"
+	"Beside-Mom-BE/modules/repositories"
+	"Beside-Mom-BE/pkg/growth"
+	"Beside-Mom-BE/pkg/report"
+	"Beside-Mom-BE/pkg/utils"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+type ReportUseCase interface {
+	GetKidReport(kidID string) ([]byte, error)
+}
+
+type ReportUseCaseImpl struct {
+	kidUseCase     KidUseCase
+	growthUseCase  GrowthUseCase
+	historyUseCase HistoryUseCase
+	kidRepo        repositories.KidsRepository
+	evaRepo        repositories.EvaluateRepository
+	appRepo        repositories.AppRepository
+	config         configs.Report
+}
+
+func NewReportUseCase(kidUseCase KidUseCase, growthUseCase GrowthUseCase, historyUseCase HistoryUseCase, kidRepo repositories.KidsRepository, evaRepo repositories.EvaluateRepository, appRepo repositories.AppRepository, config configs.Report) *ReportUseCaseImpl {
+	return &ReportUseCaseImpl{
+		kidUseCase:     kidUseCase,
+		growthUseCase:  growthUseCase,
+		historyUseCase: historyUseCase,
+		kidRepo:        kidRepo,
+		evaRepo:        evaRepo,
+		appRepo:        appRepo,
+		config:         config,
+	}
+}
+
+func (u *ReportUseCaseImpl) GetKidReport(kidID string) ([]byte, error) {
+	kidData, err := u.kidUseCase.GetKidByID(kidID)
+	if err != nil {
+		return nil, err
+	}
+
+	kid, err := u.kidRepo.GetKidByID(kidID)
+	if err != nil {
+		return nil, err
+	}
+
+	booklet := report.Booklet{
+		GeneratedAt: time.Now(),
+		Kid: report.KidProfile{
+			Name:             strings.TrimSpace(kid.Firstname + " " + kid.Lastname),
+			Username:         kid.Username,
+			Sex:              kid.Sex,
+			BloodType:        strings.TrimSpace(kid.BloodType + " " + kid.RHType),
+			BirthDate:        kid.BirthDate,
+			GestationalWeeks: kid.BeforeBirth,
+			BirthWeight:      kid.BirthWeight,
+			BirthLength:      kid.BirthLength,
+			RealAge:          formatAge(kidData["real_years"], kidData["real_months"], kidData["real_days"]),
+			AdjustedAge:      formatAge(kidData["adjusted_years"], kidData["adjusted_months"], kidData["adjusted_days"]),
+			Note:             kid.Note,
+		},
+	}
+
+	summary, err := u.growthUseCase.GetSummary(kidID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, point := range summary {
+		row := report.GrowthRow{
+			Date:              point["date"].(time.Time),
+			Age:               fmt.Sprintf("%d เดือน", point["months"].(int)),
+			Weight:            point["weight"].(float64),
+			Length:            point["length"].(float64),
+			HeadCircumference: point["head_circumference"].(*float64),
+		}
+
+		if score, ok := point["weight_for_age"].(*growth.Score); ok && score != nil {
+			row.WeightPercentile = &score.Percentile
+		}
+
+		if score, ok := point["length_for_age"].(*growth.Score); ok && score != nil {
+			row.LengthPercentile = &score.Percentile
+		}
+
+		booklet.Growths = append(booklet.Growths, row)
+	}
+
+	if chart, err := u.growthUseCase.GetChart(kidID, "weight", "png"); err == nil {
+		booklet.Chart = chart
+	}
+
+	evaluates, err := u.evaRepo.GetAllEvaluate(kidID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range evaluates {
+		results, err := u.historyUseCase.GetHistoryResult(e.EvaluatedTimes, kidID, "th")
+		if err != nil {
+			return nil, err
+		}
+
+		evaluation := report.Evaluation{
+			Period:      fmt.Sprintf("ช่วงการประเมินที่ %d", e.EvaluatedTimes),
+			Result:      utils.EvaluateResultLabel(e.Result, "th"),
+			CompletedAt: e.CompletedAt,
+		}
+
+		categoryIDs := make([]int, 0, len(results))
+		for id := range results {
+			categoryIDs = append(categoryIDs, id)
+		}
+		sort.Ints(categoryIDs)
+
+		for _, id := range categoryIDs {
+			group := results[id]
+			if len(group.Histories) == 0 {
+				continue
+			}
+
+			quiz := group.Histories[0].Quiz
+			if quiz.Period.Period != "" {
+				evaluation.Period = quiz.Period.Period
+			}
+
+			evaluation.Categories = append(evaluation.Categories, report.CategoryResult{
+				Category: quiz.Category.Category,
+				Result:   group.Solution,
+				DoneAt:   group.DoneAt,
+			})
+		}
+
+		booklet.Evaluations = append(booklet.Evaluations, evaluation)
+	}
+
+	now := time.Now()
+	apps, err := u.appRepo.GetUpcomingAppByKidID(kid.ID, time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range apps {
+		booklet.Appointments = append(booklet.Appointments, report.Appointment{
+			Title:       a.Title,
+			Date:        a.Date,
+			StartTime:   a.StartTime,
+			Building:    a.Building,
+			Doctor:      a.Doctor,
+			Requirement: a.Requirement,
+		})
+	}
+
+	return report.Render(booklet, report.Fonts{
+		Regular: u.config.FontPath,
+		Bold:    u.config.BoldFontPath,
+	})
+}
+
+func formatAge(years interface{}, months interface{}, days interface{}) string {
+	return fmt.Sprintf("%v ปี %v เดือน %v วัน", years, months, days)
+}
@@ -17,7 +17,6 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
-	"golang.org/x/crypto/bcrypt"
 )
 
 type UserUseCase interface {
@@ -27,22 +26,25 @@ type UserUseCase interface {
 	GetAllMom() ([]entities.User, error)
 	UpdateUserByIDForUser(id string, image *multipart.FileHeader, ctx *fiber.Ctx) (*entities.User, error)
 	UpdateUserByIDForAdmin(id string, user *entities.User, image *multipart.FileHeader, ctx *fiber.Ctx) (*entities.User, error)
-	DeleteUser(id string) error
 }
 
 type UserUseCaseImpl struct {
-	repo repositories.UserRepository
-	supa configs.Supabase
-	mail configs.Mail
-	chat configs.Chat
+	repo       repositories.UserRepository
+	inviteRepo repositories.InviteRepository
+	jwt        configs.JWT
+	supa       configs.Supabase
+	mail       configs.Mail
+	chat       configs.Chat
 }
 
-func NewUserUseCase(repo repositories.UserRepository, supa configs.Supabase, mail configs.Mail, chat configs.Chat) *UserUseCaseImpl {
+func NewUserUseCase(repo repositories.UserRepository, inviteRepo repositories.InviteRepository, jwt configs.JWT, supa configs.Supabase, mail configs.Mail, chat configs.Chat) *UserUseCaseImpl {
 	return &UserUseCaseImpl{
-		repo: repo,
-		supa: supa,
-		mail: mail,
-		chat: chat,
+		repo:       repo,
+		inviteRepo: inviteRepo,
+		jwt:        jwt,
+		supa:       supa,
+		mail:       mail,
+		chat:       chat,
 	}
 }
 
@@ -81,17 +83,8 @@ func (u *UserUseCaseImpl) CreateUser(user *entities.User, image *multipart.FileH
 		user.PID = fmt.Sprintf("Unnamed-Case-%03d", seq)
 	}
 
-	password, err := utils.GeneratePassword(8)
-	if err != nil {
-		return nil, errors.New("can't generate password")
-	}
-
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
-	if err != nil {
-		return nil, err
-	}
-
-	user.Password = string(hashedPassword)
+	user.Password = ""
+	user.Status = "pending"
 	if image != nil {
 		fileName := uuid.New().String() + "_title.jpg"
 		if err := ctx.SaveFile(image, "./uploads/"+fileName); err != nil {
@@ -116,7 +109,7 @@ func (u *UserUseCaseImpl) CreateUser(user *entities.User, image *multipart.FileH
 		return nil, err
 	}
 
-	if err := utils.SendPasswordMail("./assets/Passwordmail.html", *createdUser, password, u.mail); err != nil {
+	if _, err := issueInvite(u.inviteRepo, createdUser, u.jwt, u.mail); err != nil {
 		return nil, err
 	}
 
@@ -209,19 +202,6 @@ func (u *UserUseCaseImpl) UpdateUserByIDForAdmin(id string, user *entities.User,
 	return updatedUser, nil
 }
 
-func (u *UserUseCaseImpl) DeleteUser(id string) error {
-	existingUser, err := u.repo.GetUserByID(id)
-	if err != nil {
-		return err
-	}
-
-	if err := utils.DeleteImage(existingUser.ImageLink, u.supa); err != nil {
-		return err
-	}
-
-	return u.repo.DeleteUser(id)
-}
-
 func (u *UserUseCaseImpl) Chat(message string) (map[string]interface{}, error) {
 	requestBody := map[string]interface{}{
 		"message":    message,
@@ -0,0 +1,300 @@
+package database
+
+import (
+	"Beside-Mom-BE/modules/entities"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AuditActor struct {
+	UserID string
+	Role   string
+	IP     string
+}
+
+type auditActorKey struct{}
+
+type auditRedactKey struct{}
+
+const auditBeforeKey = "audit:before"
+
+const AuditActionRedact = "redact"
+
+var auditedTables = map[string]bool{
+	"kids":         true,
+	"growths":      true,
+	"histories":    true,
+	"evaluates":    true,
+	"appointments": true,
+	"referrals":    true,
+}
+
+func WithAuditActor(ctx context.Context, actor AuditActor) context.Context {
+	return context.WithValue(ctx, auditActorKey{}, actor)
+}
+
+func WithRedactedAudit(ctx context.Context) context.Context {
+	return context.WithValue(ctx, auditRedactKey{}, true)
+}
+
+func isAuditRedacted(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+
+	redacted, _ := ctx.Value(auditRedactKey{}).(bool)
+	return redacted
+}
+
+func auditActorFromContext(ctx context.Context) AuditActor {
+	if ctx == nil {
+		return AuditActor{UserID: "system", Role: "system"}
+	}
+
+	actor, ok := ctx.Value(auditActorKey{}).(AuditActor)
+	if !ok {
+		return AuditActor{UserID: "system", Role: "system"}
+	}
+
+	return actor
+}
+
+func RedactAuditLogs(tx *gorm.DB, entity string, ids interface{}, reason string) error {
+	var snapshots []entities.AuditLog
+	if err := tx.Select("id", "entity_id").
+		Where("entity = ? AND entity_id IN (?) AND action <> ?", entity, ids, AuditActionRedact).
+		Where("before IS NOT NULL OR after IS NOT NULL").
+		Find(&snapshots).Error; err != nil {
+		return err
+	}
+
+	if len(snapshots) == 0 {
+		return nil
+	}
+
+	auditIDs := make([]string, 0, len(snapshots))
+	redacted := make(map[string][]string)
+	var entityIDs []string
+	for _, snapshot := range snapshots {
+		auditIDs = append(auditIDs, snapshot.ID)
+		if _, ok := redacted[snapshot.EntityID]; !ok {
+			entityIDs = append(entityIDs, snapshot.EntityID)
+		}
+		redacted[snapshot.EntityID] = append(redacted[snapshot.EntityID], snapshot.ID)
+	}
+
+	if err := tx.Model(&entities.AuditLog{}).Where("id IN ?", auditIDs).
+		Updates(map[string]interface{}{"before": nil, "after": nil}).Error; err != nil {
+		return err
+	}
+
+	actor := auditActorFromContext(tx.Statement.Context)
+	logs := make([]entities.AuditLog, 0, len(entityIDs))
+	for _, entityID := range entityIDs {
+		after, err := json.Marshal(map[string]interface{}{
+			"reason":    reason,
+			"audit_ids": redacted[entityID],
+		})
+		if err != nil {
+			return err
+		}
+
+		logs = append(logs, entities.AuditLog{
+			ID:        uuid.New().String(),
+			ActorID:   actor.UserID,
+			ActorRole: actor.Role,
+			Action:    AuditActionRedact,
+			Entity:    entity,
+			EntityID:  entityID,
+			After:     after,
+			IP:        actor.IP,
+			CreatedAt: time.Now(),
+		})
+	}
+
+	return tx.Create(&logs).Error
+}
+
+func registerAuditCallbacks(db *gorm.DB) {
+	callbacks := []error{
+		db.Callback().Update().After("gorm:begin_transaction").Before("gorm:update").Register("audit:before_update", auditSnapshot),
+		db.Callback().Delete().After("gorm:begin_transaction").Before("gorm:delete").Register("audit:before_delete", auditSnapshot),
+		db.Callback().Create().After("gorm:create").Before("gorm:commit_or_rollback_transaction").Register("audit:after_create", auditRecord("create")),
+		db.Callback().Update().After("gorm:update").Before("gorm:commit_or_rollback_transaction").Register("audit:after_update", auditRecord("update")),
+		db.Callback().Delete().After("gorm:delete").Before("gorm:commit_or_rollback_transaction").Register("audit:after_delete", auditRecord("delete")),
+	}
+
+	for _, err := range callbacks {
+		if err != nil {
+			log.Fatalf("Failed to register audit callbacks: %v", err)
+		}
+	}
+}
+
+func isAudited(db *gorm.DB) bool {
+	return db.Error == nil && db.Statement.Schema != nil && auditedTables[db.Statement.Table]
+}
+
+func auditSnapshot(db *gorm.DB) {
+	if !isAudited(db) {
+		return
+	}
+
+	var rows []map[string]interface{}
+	if err := auditScope(db).Find(&rows).Error; err != nil {
+		log.Printf("Failed to snapshot %s for audit: %v", db.Statement.Table, err)
+		return
+	}
+
+	db.InstanceSet(auditBeforeKey, rows)
+}
+
+func auditScope(db *gorm.DB) *gorm.DB {
+	tx := db.Session(&gorm.Session{NewDB: true}).Table(db.Statement.Table)
+	if c, ok := db.Statement.Clauses["WHERE"]; ok {
+		if where, ok := c.Expression.(clause.Where); ok && len(where.Exprs) > 0 {
+			return tx.Clauses(where)
+		}
+	}
+
+	ids := auditPrimaryKeys(db)
+	if len(ids) == 0 {
+		return tx.Where("1 = 0")
+	}
+
+	return tx.Where("id IN ?", ids)
+}
+
+func auditPrimaryKeys(db *gorm.DB) []interface{} {
+	field := db.Statement.Schema.PrioritizedPrimaryField
+	if field == nil {
+		return nil
+	}
+
+	var ids []interface{}
+	collect := func(rv reflect.Value) {
+		for rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				return
+			}
+			rv = rv.Elem()
+		}
+
+		if rv.Kind() != reflect.Struct {
+			return
+		}
+
+		if id, isZero := field.ValueOf(db.Statement.Context, rv); !isZero {
+			ids = append(ids, id)
+		}
+	}
+
+	rv := db.Statement.ReflectValue
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			collect(rv.Index(i))
+		}
+	default:
+		collect(rv)
+	}
+
+	return ids
+}
+
+func auditRecord(action string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		if !isAudited(db) || db.Statement.RowsAffected == 0 {
+			return
+		}
+
+		before := make(map[string]map[string]interface{})
+		var ids []interface{}
+		if value, ok := db.InstanceGet(auditBeforeKey); ok {
+			for _, row := range value.([]map[string]interface{}) {
+				before[fmt.Sprint(row["id"])] = row
+				ids = append(ids, row["id"])
+			}
+		}
+
+		if action == "create" {
+			ids = auditPrimaryKeys(db)
+		}
+
+		if len(ids) == 0 {
+			return
+		}
+
+		after := make(map[string]map[string]interface{})
+		if action != "delete" {
+			var rows []map[string]interface{}
+			if err := db.Session(&gorm.Session{NewDB: true}).Table(db.Statement.Table).Where("id IN ?", ids).Find(&rows).Error; err != nil {
+				log.Printf("Failed to load %s for audit: %v", db.Statement.Table, err)
+				return
+			}
+
+			for _, row := range rows {
+				after[fmt.Sprint(row["id"])] = row
+			}
+		}
+
+		actor := auditActorFromContext(db.Statement.Context)
+		redacted := isAuditRedacted(db.Statement.Context)
+		var logs []entities.AuditLog
+		for _, id := range ids {
+			entityID := fmt.Sprint(id)
+			beforeJSON := auditJSON(before[entityID])
+			afterJSON := auditJSON(after[entityID])
+			if action == "update" && bytes.Equal(beforeJSON, afterJSON) {
+				continue
+			}
+
+			if redacted {
+				beforeJSON, afterJSON = nil, nil
+			}
+
+			logs = append(logs, entities.AuditLog{
+				ID:        uuid.New().String(),
+				ActorID:   actor.UserID,
+				ActorRole: actor.Role,
+				Action:    action,
+				Entity:    db.Statement.Table,
+				EntityID:  entityID,
+				Before:    beforeJSON,
+				After:     afterJSON,
+				IP:        actor.IP,
+				CreatedAt: time.Now(),
+			})
+		}
+
+		if len(logs) == 0 {
+			return
+		}
+
+		if err := db.Session(&gorm.Session{NewDB: true}).Create(&logs).Error; err != nil {
+			db.AddError(fmt.Errorf("failed to write audit log: %w", err))
+		}
+	}
+}
+
+func auditJSON(row map[string]interface{}) json.RawMessage {
+	if row == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(row)
+	if err != nil {
+		return nil
+	}
+
+	return data
+}
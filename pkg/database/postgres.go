@@ -3,6 +3,7 @@ package database
 import (
 	"Beside-Mom-BE/configs"
 	"Beside-Mom-BE/modules/entities"
+	"Beside-Mom-BE/pkg/utils"
 	"errors"
 	"fmt"
 	"log"
@@ -45,16 +46,34 @@ func InitDB(config configs.PostgreSQL) {
 		&entities.Care{},
 		&entities.OTP{},
 		&entities.Quiz{},
+		&entities.QuizVersion{},
 		&entities.Evaluate{},
 		&entities.History{},
 		&entities.Growth{},
 		&entities.Period{},
 		&entities.Category{},
+		&entities.Role{},
+		&entities.Permission{},
+		&entities.Session{},
+		&entities.Invite{},
+		&entities.KidCaregiver{},
+		&entities.AuditLog{},
+		&entities.GrowthAlert{},
+		&entities.Referral{},
 	)
 
+	migrateEvaluateResults()
+	migrateHistoryQuizConstraint()
+	registerAuditCallbacks(db)
+
 	insertRoles()
+	insertPermissions()
 	insertPeriods()
 	insertCategories()
+	backfillEvaluateDueWindows()
+	backfillQuizVersions()
+	backfillQuizExternalKeys()
+	backfillHistoryPassRules()
 	log.Println("Database connection established successfully!")
 }
 
@@ -66,83 +85,283 @@ func GetDB() *gorm.DB {
 	return db
 }
 
+var permissions = map[string]string{
+	"user:read":         "View mom accounts",
+	"user:write":        "Create, update and delete mom accounts",
+	"kid:read":          "View any kid profile and records",
+	"kid:write":         "Create and update kid profiles",
+	"growth:write":      "Record growth for any kid",
+	"growth:export":     "Export growth records for every kid",
+	"evaluate:write":    "Run developmental evaluations for any kid",
+	"appointment:read":  "View every appointment",
+	"appointment:write": "Create, update and delete appointments",
+	"content:write":     "Publish videos, care guides, questions and quizzes",
+	"staff:manage":      "Invite staff and revoke sessions",
+	"audit:read":        "View the audit trail of clinical records",
+	"referral:manage":   "Follow up failed developmental screenings",
+	"analytics:read":    "View population-level screening analytics",
+	"screening:manage":  "Manage screening periods, categories and pass rules",
+}
+
+var rolePermissions = map[string][]string{
+	"Admin": {
+		"user:read", "user:write", "kid:read", "kid:write", "growth:write", "evaluate:write",
+		"appointment:read", "appointment:write", "content:write", "staff:manage", "audit:read", "referral:manage",
+		"analytics:read", "screening:manage", "growth:export",
+	},
+	"User":      {},
+	"Nurse":     {"user:read", "kid:read", "growth:write", "evaluate:write", "appointment:read", "referral:manage"},
+	"Doctor":    {"user:read", "kid:read", "appointment:read", "appointment:write"},
+	"Volunteer": {"content:write"},
+}
+
 func insertRoles() {
-	var adminRole entities.Role
-	var userRole entities.Role
-
-	if err := db.First(&adminRole, "role_name = ?", "Admin").Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			adminRole = entities.Role{RoleName: "Admin"}
-			if err := db.Create(&adminRole).Error; err != nil {
-				log.Fatalf("Failed to insert Admin role: %v", err)
-			}
+	roleNames := []string{"Admin", "User", "Nurse", "Doctor", "Volunteer"}
+	for _, name := range roleNames {
+		var role entities.Role
+		if err := db.First(&role, "role_name = ?", name).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				role = entities.Role{RoleName: name}
+				if err := db.Create(&role).Error; err != nil {
+					log.Fatalf("Failed to insert %s role: %v", name, err)
+				}
 
-			log.Println("Admin role created successfully!")
-		} else {
-			log.Fatalf("Error checking Admin role: %v", err)
+				log.Printf("%s role created successfully!", name)
+			} else {
+				log.Fatalf("Error checking %s role: %v", name, err)
+			}
 		}
 	}
+}
 
-	if err := db.First(&userRole, "role_name = ?", "User").Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			userRole = entities.Role{RoleName: "User"}
-			if err := db.Create(&userRole).Error; err != nil {
-				log.Fatalf("Failed to insert User role: %v", err)
+func insertPermissions() {
+	for name, description := range permissions {
+		var existing entities.Permission
+		if err := db.First(&existing, "name = ?", name).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				newPermission := entities.Permission{
+					Name:        name,
+					Description: description,
+				}
+				if err := db.Create(&newPermission).Error; err != nil {
+					log.Printf("Failed to insert permission '%s': %v", name, err)
+					continue
+				}
+				log.Printf("Inserted permission: %s", name)
+			} else {
+				log.Printf("Error checking permission '%s': %v", name, err)
 			}
+		}
+	}
+
+	for roleName, names := range rolePermissions {
+		if len(names) == 0 {
+			continue
+		}
+
+		var role entities.Role
+		if err := db.First(&role, "role_name = ?", roleName).Error; err != nil {
+			log.Printf("Error checking role '%s': %v", roleName, err)
+			continue
+		}
 
-			log.Println("User role created successfully!")
-		} else {
-			log.Fatalf("Error checking User role: %v", err)
+		var perms []entities.Permission
+		if err := db.Where("name IN ?", names).Find(&perms).Error; err != nil {
+			log.Printf("Error loading permissions for role '%s': %v", roleName, err)
+			continue
+		}
+
+		if err := db.Model(&role).Association("Permissions").Append(perms); err != nil {
+			log.Printf("Failed to map permissions to role '%s': %v", roleName, err)
 		}
 	}
 }
 
 func insertPeriods() {
-	periodNames := []string{
-		"แรกเกิด", "1 เดือน", "2 เดือน", "3 - 4 เดือน",
-		"5 - 6 เดือน", "7 - 8 เดือน", "9 เดือน", "10 - 12 เดือน",
+	periods := []entities.Period{
+		{Period: "แรกเกิด", StartMonths: 0, EndMonths: 1},
+		{Period: "1 เดือน", StartMonths: 1, EndMonths: 2},
+		{Period: "2 เดือน", StartMonths: 2, EndMonths: 3},
+		{Period: "3 - 4 เดือน", StartMonths: 3, EndMonths: 5},
+		{Period: "5 - 6 เดือน", StartMonths: 5, EndMonths: 7},
+		{Period: "7 - 8 เดือน", StartMonths: 7, EndMonths: 9},
+		{Period: "9 เดือน", StartMonths: 9, EndMonths: 10},
+		{Period: "10 - 12 เดือน", StartMonths: 10, EndMonths: 13},
 	}
 
-	for _, name := range periodNames {
-		var existing entities.Period
-		if err := db.First(&existing, "period = ?", name).Error; err != nil {
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				newPeriod := entities.Period{
-					Period: name,
-				}
-				if err := db.Create(&newPeriod).Error; err != nil {
-					log.Printf("Failed to insert period '%s': %v", name, err)
-					continue
-				}
-				log.Printf("Inserted period: %s", name)
-			} else {
-				log.Printf("Error checking period '%s': %v", name, err)
+	var count int64
+	if err := db.Model(&entities.Period{}).Count(&count).Error; err != nil {
+		log.Printf("Error counting periods: %v", err)
+		return
+	}
+
+	if count == 0 {
+		for i, period := range periods {
+			newPeriod := period
+			newPeriod.SortOrder = i + 1
+			if err := db.Create(&newPeriod).Error; err != nil {
+				log.Printf("Failed to insert period '%s': %v", period.Period, err)
+				continue
 			}
+			log.Printf("Inserted period: %s", period.Period)
+		}
+		return
+	}
+
+	for _, period := range periods {
+		if err := db.Model(&entities.Period{}).Where("period = ? AND end_months = 0", period.Period).Updates(map[string]interface{}{
+			"start_months": period.StartMonths,
+			"end_months":   period.EndMonths,
+		}).Error; err != nil {
+			log.Printf("Failed to set age range for period '%s': %v", period.Period, err)
+		}
+	}
+
+	if err := db.Model(&entities.Period{}).Where("sort_order = 0").Update("sort_order", gorm.Expr("id")).Error; err != nil {
+		log.Printf("Failed to set period order: %v", err)
+	}
+}
+
+func backfillEvaluateDueWindows() {
+	var evaluates []entities.Evaluate
+	if err := db.Preload("Period").Preload("Kid").Where("due_from IS NULL OR due_to IS NULL").Find(&evaluates).Error; err != nil {
+		log.Printf("Failed to load evaluates without due windows: %v", err)
+		return
+	}
+
+	for _, eval := range evaluates {
+		if eval.Kid.ID == "" {
+			continue
+		}
+
+		dueFrom, dueTo := utils.ScreeningDueWindow(eval.Kid.BirthDate, eval.Kid.BeforeBirth, eval.Period.StartMonths, eval.Period.EndMonths)
+		if err := db.Model(&entities.Evaluate{}).Where("id = ?", eval.ID).
+			Updates(map[string]interface{}{"due_from": dueFrom, "due_to": dueTo}).Error; err != nil {
+			log.Printf("Failed to set due window for evaluate '%s': %v", eval.ID, err)
 		}
 	}
 }
 
+func migrateHistoryQuizConstraint() {
+	var deleteRule string
+	if err := db.Raw(`SELECT delete_rule FROM information_schema.referential_constraints
+		WHERE constraint_name = 'fk_histories_quiz'`).Scan(&deleteRule).Error; err != nil {
+		log.Printf("Failed to inspect histories quiz constraint: %v", err)
+		return
+	}
+
+	if deleteRule != "CASCADE" {
+		return
+	}
+
+	if err := db.Migrator().DropConstraint(&entities.History{}, "Quiz"); err != nil {
+		log.Printf("Failed to drop histories quiz constraint: %v", err)
+		return
+	}
+
+	if err := db.Migrator().CreateConstraint(&entities.History{}, "Quiz"); err != nil {
+		log.Printf("Failed to recreate histories quiz constraint: %v", err)
+	}
+}
+
+func migrateEvaluateResults() {
+	if !db.Migrator().HasColumn("evaluates", "solution") {
+		return
+	}
+
+	if err := db.Exec(`UPDATE evaluates SET result = CASE solution
+		WHEN 'ผ่านการประเมิน' THEN ?
+		WHEN 'ไม่ผ่านการประเมินบางประการ' THEN ?
+		WHEN 'กำลังประเมิน' THEN ?
+		ELSE ? END`, entities.ResultPassed, entities.ResultFailed, entities.ResultInProgress, entities.ResultPending).Error; err != nil {
+		log.Printf("Failed to convert evaluate results: %v", err)
+		return
+	}
+
+	if err := db.Migrator().DropColumn("evaluates", "solution"); err != nil {
+		log.Printf("Failed to drop evaluates.solution: %v", err)
+	}
+}
+
+func backfillQuizVersions() {
+	var quizzes []entities.Quiz
+	if err := db.Where("NOT EXISTS (SELECT 1 FROM quiz_versions WHERE quiz_versions.quiz_id = quizzes.id AND quiz_versions.version = quizzes.version)").
+		Find(&quizzes).Error; err != nil {
+		log.Printf("Failed to load quizzes without versions: %v", err)
+		return
+	}
+
+	for _, quiz := range quizzes {
+		version := entities.QuizVersion{
+			QuizID:      quiz.ID,
+			Version:     quiz.Version,
+			Question:    quiz.Question,
+			Description: quiz.Description,
+			Solution:    quiz.Solution,
+			Suggestion:  quiz.Suggestion,
+			Banner:      quiz.Banner,
+			CategoryID:  quiz.CategoryID,
+			PeriodID:    quiz.PeriodID,
+			PublishedBy: "system",
+			CreatedAt:   quiz.UpdatedAt,
+		}
+		if err := db.Create(&version).Error; err != nil {
+			log.Printf("Failed to create version for quiz %d: %v", quiz.ID, err)
+		}
+	}
+
+	if err := db.Exec(`UPDATE histories SET quiz_version_id = quiz_versions.id
+		FROM quizzes, quiz_versions
+		WHERE histories.quiz_version_id IS NULL AND histories.times > 0
+		AND quizzes.id = histories.quiz_id
+		AND quiz_versions.quiz_id = quizzes.id AND quiz_versions.version = quizzes.version`).Error; err != nil {
+		log.Printf("Failed to link histories to quiz versions: %v", err)
+	}
+}
+
+func backfillQuizExternalKeys() {
+	if err := db.Exec("UPDATE quizzes SET external_key = 'quiz-' || id WHERE external_key IS NULL OR external_key = ''").Error; err != nil {
+		log.Printf("Failed to backfill quiz external keys: %v", err)
+	}
+}
+
+func backfillHistoryPassRules() {
+	if err := db.Exec(`UPDATE histories SET min_passed = categories.min_passed
+		FROM quizzes, categories
+		WHERE histories.min_passed IS NULL AND histories.times > 0
+		AND quizzes.id = histories.quiz_id AND categories.id = quizzes.category_id`).Error; err != nil {
+		log.Printf("Failed to backfill history pass rules: %v", err)
+	}
+}
+
 func insertCategories() {
-	periodNames := []string{
+	categoryNames := []string{
 		"ด้านการเคลื่อนไหว Gross Motor (GM)", "ด้านการใช้กล้ามเนื้อมัดเล็ก และสติปัญญา Fine Motor (FM)", "ด้านการเข้าใจภาษา Receptive Language (RL)",
 		"ด้านการใช้ภาษา Expression Language (EL)", "ด้านการช่วยเหลือตนเองและสังคม Personal and Social (PS)",
 	}
 
-	for _, name := range periodNames {
-		var existing entities.Category
-		if err := db.First(&existing, "category = ?", name).Error; err != nil {
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				newCategory := entities.Category{
-					Category: name,
-				}
-				if err := db.Create(&newCategory).Error; err != nil {
-					log.Printf("Failed to insert category '%s': %v", name, err)
-					continue
-				}
-				log.Printf("Inserted category: %s", name)
-			} else {
-				log.Printf("Error checking category '%s': %v", name, err)
-			}
+	var count int64
+	if err := db.Model(&entities.Category{}).Count(&count).Error; err != nil {
+		log.Printf("Error counting categories: %v", err)
+		return
+	}
+
+	if count > 0 {
+		if err := db.Model(&entities.Category{}).Where("sort_order = 0").Update("sort_order", gorm.Expr("id")).Error; err != nil {
+			log.Printf("Failed to set category order: %v", err)
+		}
+		return
+	}
+
+	for i, name := range categoryNames {
+		newCategory := entities.Category{
+			Category:  name,
+			SortOrder: i + 1,
+		}
+		if err := db.Create(&newCategory).Error; err != nil {
+			log.Printf("Failed to insert category '%s': %v", name, err)
+			continue
 		}
+		log.Printf("Inserted category: %s", name)
 	}
 }
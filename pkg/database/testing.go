@@ -0,0 +1,7 @@
+package database
+
+import "gorm.io/gorm"
+
+func SetDB(conn *gorm.DB) {
+	db = conn
+}
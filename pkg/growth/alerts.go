@@ -0,0 +1,182 @@
+package growth
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+const (
+	SeverityLow    = "low"
+	SeverityMedium = "medium"
+	SeverityHigh   = "high"
+)
+
+const (
+	RulePercentileFaltering = "percentile_faltering"
+	RuleRapidGain           = "rapid_gain"
+	RuleWeightLoss          = "weight_loss"
+)
+
+const (
+	neonatalWindow        = 14 * 24 * time.Hour
+	neonatalWeightLossPct = 10
+)
+
+var majorPercentileLines = []float64{-2, -1.33, -0.67, 0, 0.67, 1.33, 2}
+
+type Point struct {
+	GrowthID string
+	Date     time.Time
+	Weight   float64
+	WeightZ  *float64
+}
+
+type Finding struct {
+	Rule     string
+	Severity string
+	Message  string
+}
+
+type Rule func(series []Point, index int) *Finding
+
+var DefaultRules = []Rule{
+	percentileFaltering,
+	rapidGain,
+	weightLoss,
+}
+
+func EvaluateAlerts(series []Point, index int, rules []Rule) []Finding {
+	var findings []Finding
+	if index < 0 || index >= len(series) {
+		return findings
+	}
+
+	for _, rule := range rules {
+		if finding := rule(series, index); finding != nil {
+			findings = append(findings, *finding)
+		}
+	}
+
+	return findings
+}
+
+func percentileFaltering(series []Point, index int) *Finding {
+	current := series[index].WeightZ
+	peak := peakZ(series[:index], math.Max)
+	if current == nil || peak == nil {
+		return nil
+	}
+
+	crossed := linesCrossed(*peak, *current)
+	if *current >= *peak || crossed < 2 {
+		return nil
+	}
+
+	severity := SeverityMedium
+	if crossed >= 3 {
+		severity = SeverityHigh
+	}
+
+	return &Finding{
+		Rule:     RulePercentileFaltering,
+		Severity: severity,
+		Message:  fmt.Sprintf("Weight-for-age dropped from z %.2f to %.2f, crossing %d major percentile lines", *peak, *current, crossed),
+	}
+}
+
+func rapidGain(series []Point, index int) *Finding {
+	current := series[index].WeightZ
+	lowest := peakZ(series[:index], math.Min)
+	if current == nil || lowest == nil {
+		return nil
+	}
+
+	crossed := linesCrossed(*lowest, *current)
+	if *current <= *lowest || crossed < 2 {
+		return nil
+	}
+
+	severity := SeverityLow
+	if crossed >= 3 {
+		severity = SeverityMedium
+	}
+
+	return &Finding{
+		Rule:     RuleRapidGain,
+		Severity: severity,
+		Message:  fmt.Sprintf("Weight-for-age rose from z %.2f to %.2f, crossing %d major percentile lines", *lowest, *current, crossed),
+	}
+}
+
+func weightLoss(series []Point, index int) *Finding {
+	if index == 0 {
+		return nil
+	}
+
+	birth, current := series[0], series[index]
+	if current.Date.Sub(birth.Date) <= neonatalWindow {
+		if birth.Weight <= 0 {
+			return nil
+		}
+
+		loss := (birth.Weight - current.Weight) / birth.Weight * 100
+		if loss <= neonatalWeightLossPct {
+			return nil
+		}
+
+		return &Finding{
+			Rule:     RuleWeightLoss,
+			Severity: SeverityHigh,
+			Message:  fmt.Sprintf("Weight is %.1f%% below birth weight (%.2f kg to %.2f kg) within the first two weeks", loss, birth.Weight, current.Weight),
+		}
+	}
+
+	previous := series[index-1]
+	if previous.Weight <= 0 || current.Weight >= previous.Weight {
+		return nil
+	}
+
+	loss := (previous.Weight - current.Weight) / previous.Weight * 100
+	severity := SeverityMedium
+	if loss >= 10 {
+		severity = SeverityHigh
+	}
+
+	return &Finding{
+		Rule:     RuleWeightLoss,
+		Severity: severity,
+		Message:  fmt.Sprintf("Weight dropped from %.2f kg to %.2f kg (%.1f%%) since %s", previous.Weight, current.Weight, loss, previous.Date.Format("2006-01-02")),
+	}
+}
+
+func peakZ(series []Point, pick func(float64, float64) float64) *float64 {
+	var result *float64
+	for _, p := range series {
+		if p.WeightZ == nil {
+			continue
+		}
+
+		if result == nil {
+			z := *p.WeightZ
+			result = &z
+			continue
+		}
+
+		*result = pick(*result, *p.WeightZ)
+	}
+
+	return result
+}
+
+func linesCrossed(from float64, to float64) int {
+	lo, hi := math.Min(from, to), math.Max(from, to)
+	crossed := 0
+	for _, line := range majorPercentileLines {
+		if line > lo && line <= hi {
+			crossed++
+		}
+	}
+
+	return crossed
+}
@@ -0,0 +1,129 @@
+package growth
+
+import (
+	"fmt"
+	"math"
+)
+
+type chartMetric struct {
+	indicator Indicator
+	title     string
+	unit      string
+}
+
+var chartMetrics = map[string]chartMetric{
+	"weight": {indicator: WeightForAge, title: "Weight-for-age", unit: "kg"},
+	"length": {indicator: LengthForAge, title: "Length-for-age", unit: "cm"},
+	"head":   {indicator: HeadForAge, title: "Head circumference-for-age", unit: "cm"},
+	"bmi":    {indicator: BMIForAge, title: "BMI-for-age", unit: "kg/m2"},
+}
+
+var chartPercentiles = []struct {
+	percentile float64
+	z          float64
+}{
+	{3, -1.881},
+	{15, -1.036},
+	{50, 0},
+	{85, 1.036},
+	{97, 1.881},
+}
+
+type ChartPoint struct {
+	X float64
+	Y float64
+}
+
+type PercentileCurve struct {
+	Percentile float64
+	Points     []ChartPoint
+}
+
+type Chart struct {
+	Title  string
+	Unit   string
+	Curves []PercentileCurve
+	Points []ChartPoint
+	XMin   float64
+	XMax   float64
+	YMin   float64
+	YMax   float64
+	YStep  float64
+}
+
+func IsChartMetric(metric string) bool {
+	_, ok := chartMetrics[metric]
+	return ok
+}
+
+func BuildChart(metric string, sex string, points []ChartPoint) (*Chart, error) {
+	m, ok := chartMetrics[metric]
+	if !ok {
+		return nil, fmt.Errorf("unsupported metric %q", metric)
+	}
+
+	normalized, err := NormalizeSex(sex)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildChart(fmt.Sprintf("%s (%s)", m.title, normalized), m.unit, whoTables[string(m.indicator)+"_"+normalized], points)
+}
+
+func buildChart(title string, unit string, table []lms, points []ChartPoint) (*Chart, error) {
+	if len(table) == 0 {
+		return nil, fmt.Errorf("reference table is not available")
+	}
+
+	chart := &Chart{
+		Title: title,
+		Unit:  unit,
+		XMin:  table[0].x,
+		XMax:  table[len(table)-1].x,
+	}
+
+	yMin, yMax := math.Inf(1), math.Inf(-1)
+	for _, p := range chartPercentiles {
+		curve := PercentileCurve{Percentile: p.percentile}
+		for x := chart.XMin; x <= chart.XMax; x += 0.5 {
+			params, err := lookup(table, x)
+			if err != nil {
+				return nil, err
+			}
+
+			y := measurementAt(params, p.z)
+			curve.Points = append(curve.Points, ChartPoint{X: x, Y: y})
+			yMin, yMax = math.Min(yMin, y), math.Max(yMax, y)
+		}
+		chart.Curves = append(chart.Curves, curve)
+	}
+
+	for _, p := range points {
+		if p.X < chart.XMin || p.X > chart.XMax || p.Y <= 0 {
+			continue
+		}
+
+		chart.Points = append(chart.Points, p)
+		yMin, yMax = math.Min(yMin, p.Y), math.Max(yMax, p.Y)
+	}
+
+	chart.YStep = niceStep((yMax - yMin) / 8)
+	chart.YMin = math.Floor(yMin/chart.YStep) * chart.YStep
+	chart.YMax = math.Ceil(yMax/chart.YStep) * chart.YStep
+	return chart, nil
+}
+
+func niceStep(raw float64) float64 {
+	if raw <= 0 {
+		return 1
+	}
+
+	magnitude := math.Pow(10, math.Floor(math.Log10(raw)))
+	for _, factor := range []float64{1, 2, 5, 10} {
+		if raw <= factor*magnitude {
+			return factor * magnitude
+		}
+	}
+
+	return 10 * magnitude
+}
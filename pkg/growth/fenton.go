@@ -0,0 +1,55 @@
+package growth
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+type FentonIndicator string
+
+const (
+	FentonWeight FentonIndicator = "weight"
+	FentonLength FentonIndicator = "length"
+	FentonHead   FentonIndicator = "head"
+)
+
+var ErrFentonUnavailable = errors.New("fenton reference tables are not loaded")
+
+var fentonTables = map[string][]lms{}
+
+func LoadFenton(dir string) error {
+	files := os.DirFS(dir)
+	tables := map[string][]lms{}
+	for _, indicator := range []FentonIndicator{FentonWeight, FentonLength, FentonHead} {
+		for _, sex := range []string{"boys", "girls"} {
+			name := fmt.Sprintf("%s_%s", indicator, sex)
+			table, err := loadTable(files, name+".csv")
+			if err != nil {
+				return fmt.Errorf("failed to load fenton %s: %w", name, err)
+			}
+			tables[name] = table
+		}
+	}
+
+	fentonTables = tables
+	return nil
+}
+
+func FentonAvailable() bool {
+	return len(fentonTables) > 0
+}
+
+func ComputeFenton(indicator FentonIndicator, sex string, postMenstrualWeeks float64, value float64) (*Score, error) {
+	normalized, err := NormalizeSex(sex)
+	if err != nil {
+		return nil, err
+	}
+
+	table, ok := fentonTables[string(indicator)+"_"+normalized]
+	if !ok {
+		return nil, ErrFentonUnavailable
+	}
+
+	return score(table, postMenstrualWeeks, value, false)
+}
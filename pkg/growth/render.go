@@ -0,0 +1,296 @@
+package growth
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"strings"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+)
+
+const (
+	chartWidth        = 800
+	chartHeight       = 500
+	chartMarginLeft   = 70
+	chartMarginRight  = 50
+	chartMarginTop    = 50
+	chartMarginBottom = 60
+)
+
+var (
+	colorBackground = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	colorGrid       = color.RGBA{0xe0, 0xe0, 0xe0, 0xff}
+	colorAxis       = color.RGBA{0x61, 0x61, 0x61, 0xff}
+	colorOuterBand  = color.RGBA{0xe3, 0xf2, 0xfd, 0xff}
+	colorInnerBand  = color.RGBA{0xbb, 0xde, 0xfb, 0xff}
+	colorCurve      = color.RGBA{0x64, 0xb5, 0xf6, 0xff}
+	colorMedian     = color.RGBA{0x15, 0x65, 0xc0, 0xff}
+	colorPoint      = color.RGBA{0xe6, 0x51, 0x00, 0xff}
+	colorText       = color.RGBA{0x21, 0x21, 0x21, 0xff}
+)
+
+type chartLayout struct {
+	chart *Chart
+}
+
+func (l chartLayout) x(value float64) float64 {
+	width := float64(chartWidth - chartMarginLeft - chartMarginRight)
+	return chartMarginLeft + (value-l.chart.XMin)/(l.chart.XMax-l.chart.XMin)*width
+}
+
+func (l chartLayout) y(value float64) float64 {
+	height := float64(chartHeight - chartMarginTop - chartMarginBottom)
+	return chartMarginTop + height - (value-l.chart.YMin)/(l.chart.YMax-l.chart.YMin)*height
+}
+
+func (l chartLayout) project(points []ChartPoint) []ChartPoint {
+	projected := make([]ChartPoint, len(points))
+	for i, p := range points {
+		projected[i] = ChartPoint{X: l.x(p.X), Y: l.y(p.Y)}
+	}
+
+	return projected
+}
+
+func (l chartLayout) band(lower PercentileCurve, upper PercentileCurve) []ChartPoint {
+	polygon := l.project(upper.Points)
+	for i := len(lower.Points) - 1; i >= 0; i-- {
+		polygon = append(polygon, ChartPoint{X: l.x(lower.Points[i].X), Y: l.y(lower.Points[i].Y)})
+	}
+
+	return polygon
+}
+
+func (l chartLayout) yTicks() []float64 {
+	var ticks []float64
+	for v := l.chart.YMin; v <= l.chart.YMax+l.chart.YStep/2; v += l.chart.YStep {
+		ticks = append(ticks, v)
+	}
+
+	return ticks
+}
+
+func (l chartLayout) xTicks() []float64 {
+	var ticks []float64
+	for v := math.Ceil(l.chart.XMin); v <= l.chart.XMax; v++ {
+		ticks = append(ticks, v)
+	}
+
+	return ticks
+}
+
+func formatTick(value float64, step float64) string {
+	if step >= 1 {
+		return fmt.Sprintf("%.0f", value)
+	}
+
+	return fmt.Sprintf("%.1f", value)
+}
+
+func RenderSVG(chart *Chart) []byte {
+	l := chartLayout{chart: chart}
+	left, right := float64(chartMarginLeft), float64(chartWidth-chartMarginRight)
+	top, bottom := float64(chartMarginTop), float64(chartHeight-chartMarginBottom)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif">`+"\n", chartWidth, chartHeight, chartWidth, chartHeight)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="%s"/>`+"\n", chartWidth, chartHeight, hexColor(colorBackground))
+	fmt.Fprintf(&b, `<text x="%d" y="30" font-size="18" text-anchor="middle" fill="%s">%s</text>`+"\n", chartWidth/2, hexColor(colorText), escapeSVG(chart.Title))
+
+	for _, v := range l.xTicks() {
+		x := l.x(v)
+		fmt.Fprintf(&b, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s"/>`+"\n", x, top, x, bottom, hexColor(colorGrid))
+		if int(v)%3 == 0 {
+			fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" font-size="12" text-anchor="middle" fill="%s">%.0f</text>`+"\n", x, bottom+18, hexColor(colorText), v)
+		}
+	}
+
+	for _, v := range l.yTicks() {
+		y := l.y(v)
+		fmt.Fprintf(&b, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s"/>`+"\n", left, y, right, y, hexColor(colorGrid))
+		fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" font-size="12" text-anchor="end" fill="%s">%s</text>`+"\n", left-8, y+4, hexColor(colorText), formatTick(v, chart.YStep))
+	}
+
+	if len(chart.Curves) == len(chartPercentiles) {
+		fmt.Fprintf(&b, `<polygon points="%s" fill="%s"/>`+"\n", svgPoints(l.band(chart.Curves[0], chart.Curves[4])), hexColor(colorOuterBand))
+		fmt.Fprintf(&b, `<polygon points="%s" fill="%s"/>`+"\n", svgPoints(l.band(chart.Curves[1], chart.Curves[3])), hexColor(colorInnerBand))
+	}
+
+	for _, curve := range chart.Curves {
+		stroke, width := colorCurve, 1
+		if curve.Percentile == 50 {
+			stroke, width = colorMedian, 2
+		}
+
+		fmt.Fprintf(&b, `<polyline points="%s" fill="none" stroke="%s" stroke-width="%d"/>`+"\n", svgPoints(l.project(curve.Points)), hexColor(stroke), width)
+		last := curve.Points[len(curve.Points)-1]
+		fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" font-size="11" fill="%s">P%.0f</text>`+"\n", l.x(last.X)+6, l.y(last.Y)+4, hexColor(stroke), curve.Percentile)
+	}
+
+	fmt.Fprintf(&b, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s"/>`+"\n", left, bottom, right, bottom, hexColor(colorAxis))
+	fmt.Fprintf(&b, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s"/>`+"\n", left, top, left, bottom, hexColor(colorAxis))
+	fmt.Fprintf(&b, `<text x="%.1f" y="%d" font-size="13" text-anchor="middle" fill="%s">Age (months)</text>`+"\n", (left+right)/2, chartHeight-15, hexColor(colorText))
+	fmt.Fprintf(&b, `<text x="20" y="%.1f" font-size="13" text-anchor="middle" fill="%s" transform="rotate(-90 20 %.1f)">%s</text>`+"\n", (top+bottom)/2, hexColor(colorText), (top+bottom)/2, escapeSVG(chart.Unit))
+
+	if len(chart.Points) > 1 {
+		fmt.Fprintf(&b, `<polyline points="%s" fill="none" stroke="%s" stroke-width="2"/>`+"\n", svgPoints(l.project(chart.Points)), hexColor(colorPoint))
+	}
+
+	for _, p := range l.project(chart.Points) {
+		fmt.Fprintf(&b, `<circle cx="%.1f" cy="%.1f" r="4" fill="%s"/>`+"\n", p.X, p.Y, hexColor(colorPoint))
+	}
+
+	b.WriteString("</svg>\n")
+	return []byte(b.String())
+}
+
+func RenderPNG(chart *Chart) ([]byte, error) {
+	l := chartLayout{chart: chart}
+	left, right := float64(chartMarginLeft), float64(chartWidth-chartMarginRight)
+	top, bottom := float64(chartMarginTop), float64(chartHeight-chartMarginBottom)
+
+	img := image.NewRGBA(image.Rect(0, 0, chartWidth, chartHeight))
+	draw.Draw(img, img.Bounds(), image.NewUniform(colorBackground), image.Point{}, draw.Src)
+
+	for _, v := range l.xTicks() {
+		x := l.x(v)
+		strokeLine(img, []ChartPoint{{X: x, Y: top}, {X: x, Y: bottom}}, 1, colorGrid)
+		if int(v)%3 == 0 {
+			label := fmt.Sprintf("%.0f", v)
+			drawText(img, label, x-float64(textWidth(label))/2, bottom+18, colorText)
+		}
+	}
+
+	for _, v := range l.yTicks() {
+		y := l.y(v)
+		strokeLine(img, []ChartPoint{{X: left, Y: y}, {X: right, Y: y}}, 1, colorGrid)
+		label := formatTick(v, chart.YStep)
+		drawText(img, label, left-8-float64(textWidth(label)), y+4, colorText)
+	}
+
+	if len(chart.Curves) == len(chartPercentiles) {
+		fillPolygon(img, l.band(chart.Curves[0], chart.Curves[4]), colorOuterBand)
+		fillPolygon(img, l.band(chart.Curves[1], chart.Curves[3]), colorInnerBand)
+	}
+
+	for _, curve := range chart.Curves {
+		stroke, width := colorCurve, 1.0
+		if curve.Percentile == 50 {
+			stroke, width = colorMedian, 2
+		}
+
+		strokeLine(img, l.project(curve.Points), width, stroke)
+		last := curve.Points[len(curve.Points)-1]
+		drawText(img, fmt.Sprintf("P%.0f", curve.Percentile), l.x(last.X)+6, l.y(last.Y)+4, stroke)
+	}
+
+	strokeLine(img, []ChartPoint{{X: left, Y: bottom}, {X: right, Y: bottom}}, 1, colorAxis)
+	strokeLine(img, []ChartPoint{{X: left, Y: top}, {X: left, Y: bottom}}, 1, colorAxis)
+	drawText(img, chart.Title, float64(chartWidth-textWidth(chart.Title))/2, 30, colorText)
+	drawText(img, "Age (months)", (left+right-float64(textWidth("Age (months)")))/2, chartHeight-15, colorText)
+	drawText(img, chart.Unit, 10, (top+bottom)/2, colorText)
+
+	projected := l.project(chart.Points)
+	if len(projected) > 1 {
+		strokeLine(img, projected, 2, colorPoint)
+	}
+
+	for _, p := range projected {
+		fillCircle(img, p, 4, colorPoint)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := png.Encode(buf, img); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func fillPolygon(img *image.RGBA, points []ChartPoint, c color.Color) {
+	if len(points) < 3 {
+		return
+	}
+
+	r := vector.NewRasterizer(chartWidth, chartHeight)
+	r.MoveTo(float32(points[0].X), float32(points[0].Y))
+	for _, p := range points[1:] {
+		r.LineTo(float32(p.X), float32(p.Y))
+	}
+	r.ClosePath()
+	r.Draw(img, img.Bounds(), image.NewUniform(c), image.Point{})
+}
+
+func strokeLine(img *image.RGBA, points []ChartPoint, width float64, c color.Color) {
+	half := width / 2
+	for i := 1; i < len(points); i++ {
+		a, b := points[i-1], points[i]
+		dx, dy := b.X-a.X, b.Y-a.Y
+		length := math.Hypot(dx, dy)
+		if length == 0 {
+			continue
+		}
+
+		nx, ny := -dy/length*half, dx/length*half
+		fillPolygon(img, []ChartPoint{
+			{X: a.X + nx, Y: a.Y + ny},
+			{X: b.X + nx, Y: b.Y + ny},
+			{X: b.X - nx, Y: b.Y - ny},
+			{X: a.X - nx, Y: a.Y - ny},
+		}, c)
+
+		if width > 1 && i < len(points)-1 {
+			fillCircle(img, b, half, c)
+		}
+	}
+}
+
+func fillCircle(img *image.RGBA, center ChartPoint, radius float64, c color.Color) {
+	const segments = 24
+	points := make([]ChartPoint, segments)
+	for i := range points {
+		angle := 2 * math.Pi * float64(i) / segments
+		points[i] = ChartPoint{X: center.X + radius*math.Cos(angle), Y: center.Y + radius*math.Sin(angle)}
+	}
+
+	fillPolygon(img, points, c)
+}
+
+func drawText(img *image.RGBA, text string, x float64, y float64, c color.Color) {
+	d := font.Drawer{
+		Dst:  img,
+		Src:  image.NewUniform(c),
+		Face: basicfont.Face7x13,
+		Dot:  fixed.P(int(math.Round(x)), int(math.Round(y))),
+	}
+	d.DrawString(text)
+}
+
+func textWidth(text string) int {
+	return font.MeasureString(basicfont.Face7x13, text).Round()
+}
+
+func svgPoints(points []ChartPoint) string {
+	parts := make([]string, len(points))
+	for i, p := range points {
+		parts[i] = fmt.Sprintf("%.1f,%.1f", p.X, p.Y)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func hexColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+func escapeSVG(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;").Replace(text)
+}
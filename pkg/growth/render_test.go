@@ -0,0 +1,83 @@
+package growth
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+func goldenChart(t *testing.T) *Chart {
+	t.Helper()
+	table := []lms{
+		{x: 0, l: 0.3487, m: 3.3464, s: 0.14602},
+		{x: 3, l: 0.1738, m: 6.3762, s: 0.11727},
+		{x: 6, l: 0.1257, m: 7.934, s: 0.11080},
+		{x: 9, l: 0.0917, m: 8.9014, s: 0.10881},
+		{x: 12, l: 0.0644, m: 9.6479, s: 0.10925},
+	}
+	points := []ChartPoint{
+		{X: 0, Y: 3.1},
+		{X: 1.5, Y: 4.6},
+		{X: 4, Y: 6.9},
+		{X: 7.25, Y: 8.1},
+		{X: 11, Y: 10.4},
+		{X: 14, Y: 11},
+	}
+
+	chart, err := buildChart("Weight-for-age (boys)", "kg", table, points)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return chart
+}
+
+func assertGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.MkdirAll("testdata", 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run go test -update to create it)", err)
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s does not match the rendered chart (run go test -update after checking the change)", path)
+	}
+}
+
+func TestBuildChartDropsOutOfRangePoints(t *testing.T) {
+	chart := goldenChart(t)
+	if len(chart.Points) != 5 {
+		t.Fatalf("got %d points, want 5", len(chart.Points))
+	}
+
+	if chart.XMin != 0 || chart.XMax != 12 {
+		t.Errorf("got x range %v-%v, want 0-12", chart.XMin, chart.XMax)
+	}
+}
+
+func TestRenderSVGGolden(t *testing.T) {
+	assertGolden(t, "weight_boys.svg", RenderSVG(goldenChart(t)))
+}
+
+func TestRenderPNGGolden(t *testing.T) {
+	png, err := RenderPNG(goldenChart(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertGolden(t, "weight_boys.png", png)
+}
@@ -0,0 +1,318 @@
+package growth
+
+import (
+	"embed"
+	"encoding/csv"
+	"fmt"
+	"io/fs"
+	"math"
+	"strconv"
+	"strings"
+)
+
+//go:embed who/*.csv
+var referenceFiles embed.FS
+
+type Indicator string
+
+const (
+	WeightForAge    Indicator = "wfa"
+	LengthForAge    Indicator = "lfa"
+	WeightForLength Indicator = "wfl"
+	HeadForAge      Indicator = "hcfa"
+	BMIForAge       Indicator = "bfa"
+)
+
+const daysPerMonth = 30.4375
+
+type lms struct {
+	x float64
+	l float64
+	m float64
+	s float64
+}
+
+type Score struct {
+	Z          float64 `json:"z_score"`
+	Percentile float64 `json:"percentile"`
+}
+
+const (
+	ReferenceWHO    = "who"
+	ReferenceFenton = "fenton"
+)
+
+const (
+	TermWeeks        = 40
+	PretermWeeks     = 37
+	fentonLimitWeeks = 50
+)
+
+type Assessment struct {
+	Reference          string  `json:"reference"`
+	AgeMonths          float64 `json:"age_months"`
+	CorrectedAgeMonths float64 `json:"corrected_age_months"`
+	PostMenstrualWeeks float64 `json:"post_menstrual_weeks"`
+	WeightForAge       *Score  `json:"weight_for_age"`
+	LengthForAge       *Score  `json:"length_for_age"`
+	WeightForLength    *Score  `json:"weight_for_length"`
+	HeadForAge         *Score  `json:"head_circumference_for_age"`
+	BMIForAge          *Score  `json:"bmi_for_age"`
+}
+
+type Measurement struct {
+	Length            float64
+	Weight            float64
+	HeadCircumference *float64
+}
+
+var whoTables = map[string][]lms{}
+
+func init() {
+	for _, indicator := range []Indicator{WeightForAge, LengthForAge, WeightForLength, HeadForAge, BMIForAge} {
+		for _, sex := range []string{"boys", "girls"} {
+			name := fmt.Sprintf("%s_%s", indicator, sex)
+			table, err := loadTable(referenceFiles, "who/"+name+".csv")
+			if err != nil {
+				panic(fmt.Sprintf("growth: failed to load %s: %v", name, err))
+			}
+			whoTables[name] = table
+		}
+	}
+}
+
+func loadTable(files fs.FS, path string) ([]lms, error) {
+	file, err := files.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) < 2 {
+		return nil, fmt.Errorf("%s has no rows", path)
+	}
+
+	var table []lms
+	for _, record := range records[1:] {
+		if len(record) < 4 {
+			return nil, fmt.Errorf("%s has a row with %d columns", path, len(record))
+		}
+
+		var values [4]float64
+		for i := range values {
+			values[i], err = strconv.ParseFloat(record[i], 64)
+			if err != nil {
+				return nil, err
+			}
+		}
+		table = append(table, lms{x: values[0], l: values[1], m: values[2], s: values[3]})
+	}
+
+	return table, nil
+}
+
+func NormalizeSex(sex string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(sex)) {
+	case "male", "m", "boy", "ชาย", "เด็กชาย":
+		return "boys", nil
+	case "female", "f", "girl", "หญิง", "เด็กหญิง":
+		return "girls", nil
+	}
+
+	return "", fmt.Errorf("unsupported sex %q", sex)
+}
+
+func AgeInMonths(days float64) float64 {
+	return days / daysPerMonth
+}
+
+func BMI(weight float64, length float64) float64 {
+	if length <= 0 {
+		return 0
+	}
+
+	meters := length / 100
+	return round(weight/(meters*meters), 2)
+}
+
+func IsPreterm(gestationalWeeks int) bool {
+	return gestationalWeeks > 0 && gestationalWeeks < PretermWeeks
+}
+
+func CorrectionDays(gestationalWeeks int) int {
+	if !IsPreterm(gestationalWeeks) {
+		return 0
+	}
+
+	return (TermWeeks - gestationalWeeks) * 7
+}
+
+func CorrectedAgeDays(gestationalWeeks int, ageDays float64) float64 {
+	corrected := ageDays - float64(CorrectionDays(gestationalWeeks))
+	if corrected < 0 {
+		return 0
+	}
+
+	return corrected
+}
+
+func Assess(sex string, gestationalWeeks int, ageDays float64, m Measurement) (*Assessment, error) {
+	if _, err := NormalizeSex(sex); err != nil {
+		return nil, err
+	}
+
+	assessment := Assessment{
+		Reference:          ReferenceWHO,
+		AgeMonths:          round(AgeInMonths(ageDays), 1),
+		CorrectedAgeMonths: round(AgeInMonths(CorrectedAgeDays(gestationalWeeks, ageDays)), 1),
+	}
+
+	if gestationalWeeks > 0 {
+		assessment.PostMenstrualWeeks = round(float64(gestationalWeeks)+ageDays/7, 1)
+	}
+
+	if IsPreterm(gestationalWeeks) && assessment.PostMenstrualWeeks < fentonLimitWeeks {
+		if !FentonAvailable() {
+			return nil, ErrFentonUnavailable
+		}
+
+		assessment.Reference = ReferenceFenton
+		if score, err := ComputeFenton(FentonWeight, sex, assessment.PostMenstrualWeeks, m.Weight); err == nil {
+			assessment.WeightForAge = score
+		}
+
+		if score, err := ComputeFenton(FentonLength, sex, assessment.PostMenstrualWeeks, m.Length); err == nil {
+			assessment.LengthForAge = score
+		}
+
+		if m.HeadCircumference != nil {
+			if score, err := ComputeFenton(FentonHead, sex, assessment.PostMenstrualWeeks, *m.HeadCircumference); err == nil {
+				assessment.HeadForAge = score
+			}
+		}
+
+		return &assessment, nil
+	}
+
+	ageMonths := AgeInMonths(CorrectedAgeDays(gestationalWeeks, ageDays))
+
+	if score, err := Compute(WeightForAge, sex, ageMonths, m.Weight); err == nil {
+		assessment.WeightForAge = score
+	}
+
+	if score, err := Compute(LengthForAge, sex, ageMonths, m.Length); err == nil {
+		assessment.LengthForAge = score
+	}
+
+	if score, err := Compute(WeightForLength, sex, m.Length, m.Weight); err == nil {
+		assessment.WeightForLength = score
+	}
+
+	if score, err := Compute(BMIForAge, sex, ageMonths, BMI(m.Weight, m.Length)); err == nil {
+		assessment.BMIForAge = score
+	}
+
+	if m.HeadCircumference != nil {
+		if score, err := Compute(HeadForAge, sex, ageMonths, *m.HeadCircumference); err == nil {
+			assessment.HeadForAge = score
+		}
+	}
+
+	return &assessment, nil
+}
+
+func Compute(indicator Indicator, sex string, x float64, value float64) (*Score, error) {
+	normalized, err := NormalizeSex(sex)
+	if err != nil {
+		return nil, err
+	}
+
+	return score(whoTables[string(indicator)+"_"+normalized], x, value, indicator != LengthForAge && indicator != HeadForAge)
+}
+
+func score(table []lms, x float64, value float64, adjustTails bool) (*Score, error) {
+	params, err := lookup(table, x)
+	if err != nil {
+		return nil, err
+	}
+
+	if value <= 0 {
+		return nil, fmt.Errorf("measurement must be positive")
+	}
+
+	z := zScore(params, value, adjustTails)
+	return &Score{
+		Z:          round(z, 2),
+		Percentile: round(Percentile(z), 1),
+	}, nil
+}
+
+func lookup(table []lms, x float64) (lms, error) {
+	if len(table) == 0 {
+		return lms{}, fmt.Errorf("reference table is not available")
+	}
+
+	if x < table[0].x || x > table[len(table)-1].x {
+		return lms{}, fmt.Errorf("%.1f is outside the reference range %.1f-%.1f", x, table[0].x, table[len(table)-1].x)
+	}
+
+	for i := 1; i < len(table); i++ {
+		if x <= table[i].x {
+			lo, hi := table[i-1], table[i]
+			t := (x - lo.x) / (hi.x - lo.x)
+			return lms{
+				x: x,
+				l: lo.l + t*(hi.l-lo.l),
+				m: lo.m + t*(hi.m-lo.m),
+				s: lo.s + t*(hi.s-lo.s),
+			}, nil
+		}
+	}
+
+	return table[0], nil
+}
+
+func zScore(p lms, value float64, adjustTails bool) float64 {
+	var z float64
+	if p.l == 0 {
+		z = math.Log(value/p.m) / p.s
+	} else {
+		z = (math.Pow(value/p.m, p.l) - 1) / (p.l * p.s)
+	}
+
+	if !adjustTails || math.Abs(z) <= 3 {
+		return z
+	}
+
+	if z > 3 {
+		sd3 := measurementAt(p, 3)
+		sd23 := sd3 - measurementAt(p, 2)
+		return 3 + (value-sd3)/sd23
+	}
+
+	sd3 := measurementAt(p, -3)
+	sd23 := measurementAt(p, -2) - sd3
+	return -3 + (value-sd3)/sd23
+}
+
+func measurementAt(p lms, z float64) float64 {
+	if p.l == 0 {
+		return p.m * math.Exp(p.s*z)
+	}
+
+	return p.m * math.Pow(1+p.l*p.s*z, 1/p.l)
+}
+
+func Percentile(z float64) float64 {
+	return 50 * (1 + math.Erf(z/math.Sqrt2))
+}
+
+func round(value float64, places int) float64 {
+	factor := math.Pow(10, float64(places))
+	return math.Round(value*factor) / factor
+}
@@ -2,12 +2,18 @@ package middlewares
 
 import (
 	"Beside-Mom-BE/configs"
+	"Beside-Mom-BE/modules/repositories"
+	"Beside-Mom-BE/pkg/database"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
+	"gorm.io/gorm"
 )
 
-func JWTMiddleware(config configs.JWT) fiber.Handler {
+func JWTMiddleware(config configs.JWT, db *gorm.DB) fiber.Handler {
+	sessionRepo := repositories.NewGormSessionRepository(db)
+	roleRepo := repositories.NewGormRoleRepository(db)
 	return func(ctx *fiber.Ctx) error {
 		tokenString := ctx.Get("Authorization")
 		if tokenString == "" || len(tokenString) < 8 {
@@ -46,22 +52,78 @@ func JWTMiddleware(config configs.JWT) fiber.Handler {
 			})
 		}
 
+		sessionID, ok := claims["session_id"].(string)
+		if !ok || sessionID == "" {
+			return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"status":      "Unauthorized",
+				"status_code": fiber.StatusUnauthorized,
+				"message":     "Invalid token claims",
+				"result":      nil,
+			})
+		}
+
+		session, err := sessionRepo.GetSessionByID(sessionID)
+		if err != nil || session.RevokedAt != nil || time.Now().After(session.ExpiresAt) {
+			return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"status":      "Unauthorized",
+				"status_code": fiber.StatusUnauthorized,
+				"message":     "Session has been revoked or expired",
+				"result":      nil,
+			})
+		}
+
+		role, _ := claims["role"].(string)
+		permissions, err := roleRepo.GetPermissionsByRoleName(role)
+		if err != nil {
+			return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"status":      "Error",
+				"status_code": fiber.StatusInternalServerError,
+				"message":     "Failed to load permissions",
+				"result":      nil,
+			})
+		}
+
+		userID, _ := claims["user_id"].(string)
+		ctx.SetUserContext(database.WithAuditActor(ctx.UserContext(), database.AuditActor{
+			UserID: userID,
+			Role:   role,
+			IP:     ctx.IP(),
+		}))
+
 		ctx.Locals("user_id", claims["user_id"])
-		ctx.Locals("role", claims["role"])
+		ctx.Locals("role", role)
+		ctx.Locals("session_id", sessionID)
+		ctx.Locals("permissions", permissions)
 		return ctx.Next()
 	}
 }
 
-func AdminMiddleware(ctx *fiber.Ctx) error {
-	userRole, ok := ctx.Locals("role").(string)
-	if !ok || userRole != "Admin" {
-		return ctx.Status(fiber.StatusForbidden).JSON(fiber.Map{
-			"status":      "Error",
-			"status_code": fiber.StatusForbidden,
-			"message":     "Forbidden: Admin access required",
-			"result":      nil,
-		})
+func RequirePermission(permission string) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		if !HasPermission(ctx, permission) {
+			return ctx.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"status":      "Error",
+				"status_code": fiber.StatusForbidden,
+				"message":     "Forbidden: Missing permission " + permission,
+				"result":      nil,
+			})
+		}
+
+		return ctx.Next()
+	}
+}
+
+func HasPermission(ctx *fiber.Ctx, permission string) bool {
+	permissions, ok := ctx.Locals("permissions").([]string)
+	if !ok {
+		return false
+	}
+
+	for _, p := range permissions {
+		if p == permission {
+			return true
+		}
 	}
 
-	return ctx.Next()
+	return false
 }
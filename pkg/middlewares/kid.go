@@ -0,0 +1,93 @@
+package middlewares
+
+import (
+	"Beside-Mom-BE/modules/repositories"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+var caregiverAccess = map[string][]string{
+	"kid:read":     {"view", "record_growth"},
+	"growth:write": {"record_growth"},
+}
+
+func KidOwnerMiddleware(db *gorm.DB, permission string) fiber.Handler {
+	kidRepo := repositories.NewGormKidsRepository(db)
+	caregiverRepo := repositories.NewGormCaregiverRepository(db)
+	return func(ctx *fiber.Ctx) error {
+		return authorizeKid(ctx, kidRepo, caregiverRepo, ctx.Params("id"), permission)
+	}
+}
+
+func GrowthOwnerMiddleware(db *gorm.DB, permission string) fiber.Handler {
+	kidRepo := repositories.NewGormKidsRepository(db)
+	caregiverRepo := repositories.NewGormCaregiverRepository(db)
+	growthRepo := repositories.NewGormGrowthRepository(db)
+	return func(ctx *fiber.Ctx) error {
+		growth, err := growthRepo.GetGrowthByID(ctx.Params("id"))
+		if err != nil || growth == nil || growth.KidID == "" {
+			return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"status":      "Error",
+				"status_code": fiber.StatusNotFound,
+				"message":     "Growth not found",
+				"result":      nil,
+			})
+		}
+
+		return authorizeKid(ctx, kidRepo, caregiverRepo, growth.KidID, permission)
+	}
+}
+
+func authorizeKid(ctx *fiber.Ctx, kidRepo repositories.KidsRepository, caregiverRepo repositories.CaregiverRepository, kidID string, permission string) error {
+	userID, ok := ctx.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusUnauthorized,
+			"message":     "Unauthorized: Missing user ID",
+			"result":      nil,
+		})
+	}
+
+	ownerID, err := kidRepo.GetKidOwnerID(kidID)
+	if err != nil {
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusNotFound,
+			"message":     "Kid not found",
+			"result":      nil,
+		})
+	}
+
+	if ownerID == userID || HasPermission(ctx, permission) || isCaregiver(caregiverRepo, kidID, userID, permission) {
+		return ctx.Next()
+	}
+
+	return ctx.Status(fiber.StatusForbidden).JSON(fiber.Map{
+		"status":      "Error",
+		"status_code": fiber.StatusForbidden,
+		"message":     "Forbidden: You do not have access to this kid",
+		"result":      nil,
+	})
+}
+
+func isCaregiver(caregiverRepo repositories.CaregiverRepository, kidID string, userID string, permission string) bool {
+	allowed, ok := caregiverAccess[permission]
+	if !ok {
+		return false
+	}
+
+	caregiver, err := caregiverRepo.GetActiveCaregiver(kidID, userID)
+	if err != nil {
+		return false
+	}
+
+	for _, access := range allowed {
+		if caregiver.Access == access {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,326 @@
+package report
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+const fontFamily = "Sarabun"
+
+const (
+	regularFontFile = "fonts/Sarabun-Regular.ttf"
+	boldFontFile    = "fonts/Sarabun-Bold.ttf"
+)
+
+//go:embed fonts
+var embeddedFonts embed.FS
+
+type Fonts struct {
+	Regular string
+	Bold    string
+}
+
+type Booklet struct {
+	GeneratedAt  time.Time
+	Kid          KidProfile
+	Growths      []GrowthRow
+	Chart        []byte
+	Evaluations  []Evaluation
+	Appointments []Appointment
+}
+
+type KidProfile struct {
+	Name             string
+	Username         string
+	Sex              string
+	BloodType        string
+	BirthDate        time.Time
+	GestationalWeeks int
+	BirthWeight      float64
+	BirthLength      float64
+	RealAge          string
+	AdjustedAge      string
+	Note             string
+}
+
+type GrowthRow struct {
+	Date              time.Time
+	Age               string
+	Weight            float64
+	Length            float64
+	HeadCircumference *float64
+	WeightPercentile  *float64
+	LengthPercentile  *float64
+}
+
+type Evaluation struct {
+	Period      string
+	Result      string
+	CompletedAt *time.Time
+	Categories  []CategoryResult
+}
+
+type CategoryResult struct {
+	Category string
+	Result   string
+	DoneAt   *time.Time
+}
+
+type Appointment struct {
+	Title       string
+	Date        time.Time
+	StartTime   time.Time
+	Building    string
+	Doctor      string
+	Requirement string
+}
+
+func Render(b Booklet, fonts Fonts) ([]byte, error) {
+	regular, err := loadFont(fonts.Regular, regularFontFile)
+	if err != nil {
+		return nil, err
+	}
+
+	bold, err := loadFont(fonts.Bold, boldFontFile)
+	if err != nil {
+		return nil, err
+	}
+
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.AddUTF8FontFromBytes(fontFamily, "", regular)
+	pdf.AddUTF8FontFromBytes(fontFamily, "B", bold)
+	pdf.SetTitle("Health booklet - "+b.Kid.Name, true)
+	pdf.SetAutoPageBreak(true, 15)
+	pdf.AliasNbPages("")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-12)
+		pdf.SetFont(fontFamily, "", 9)
+		pdf.CellFormat(0, 5, fmt.Sprintf("พิมพ์เมื่อ %s", b.GeneratedAt.Format("2006-01-02 15:04")), "", 0, "L", false, 0, "")
+		pdf.CellFormat(0, 5, fmt.Sprintf("หน้า %d/{nb}", pdf.PageNo()), "", 0, "R", false, 0, "")
+	})
+	pdf.AddPage()
+
+	pdf.SetFont(fontFamily, "B", 20)
+	pdf.CellFormat(0, 12, "สมุดบันทึกสุขภาพเด็ก", "", 1, "C", false, 0, "")
+	pdf.Ln(2)
+
+	writeProfile(pdf, b.Kid)
+	writeGrowth(pdf, b.Growths, b.Chart)
+	writeEvaluations(pdf, b.Evaluations)
+	writeAppointments(pdf, b.Appointments)
+
+	if err := pdf.Error(); err != nil {
+		return nil, err
+	}
+
+	buf := new(bytes.Buffer)
+	if err := pdf.Output(buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func CheckFonts(fonts Fonts) error {
+	if _, err := loadFont(fonts.Regular, regularFontFile); err != nil {
+		return err
+	}
+
+	_, err := loadFont(fonts.Bold, boldFontFile)
+	return err
+}
+
+func loadFont(override string, embedded string) ([]byte, error) {
+	if override != "" {
+		font, err := os.ReadFile(override)
+		if err != nil {
+			return nil, fmt.Errorf("report font %s is not available", override)
+		}
+		return font, nil
+	}
+
+	font, err := embeddedFonts.ReadFile(embedded)
+	if err != nil {
+		return nil, fmt.Errorf("report font %s is not embedded", embedded)
+	}
+
+	return font, nil
+}
+
+func writeHeading(pdf *gofpdf.Fpdf, title string) {
+	pdf.Ln(4)
+	pdf.SetFont(fontFamily, "B", 14)
+	pdf.SetFillColor(227, 242, 253)
+	pdf.CellFormat(0, 9, title, "", 1, "L", true, 0, "")
+	pdf.Ln(2)
+}
+
+func writeProfile(pdf *gofpdf.Fpdf, kid KidProfile) {
+	writeHeading(pdf, "ข้อมูลเด็ก")
+
+	gestation := "-"
+	if kid.GestationalWeeks > 0 {
+		gestation = fmt.Sprintf("%d สัปดาห์", kid.GestationalWeeks)
+	}
+
+	rows := [][2]string{
+		{"ชื่อ-นามสกุล", kid.Name},
+		{"ชื่อเล่น", kid.Username},
+		{"เพศ", kid.Sex},
+		{"หมู่เลือด", kid.BloodType},
+		{"วันเกิด", kid.BirthDate.Format("2006-01-02")},
+		{"อายุครรภ์เมื่อคลอด", gestation},
+		{"น้ำหนักแรกเกิด", fmt.Sprintf("%.2f กก.", kid.BirthWeight)},
+		{"ความยาวแรกเกิด", fmt.Sprintf("%.1f ซม.", kid.BirthLength)},
+		{"อายุจริง", kid.RealAge},
+		{"อายุปรับแก้", kid.AdjustedAge},
+	}
+
+	for _, row := range rows {
+		pdf.SetFont(fontFamily, "B", 11)
+		pdf.CellFormat(50, 7, row[0], "", 0, "L", false, 0, "")
+		pdf.SetFont(fontFamily, "", 11)
+		pdf.CellFormat(0, 7, row[1], "", 1, "L", false, 0, "")
+	}
+
+	if kid.Note != "" {
+		pdf.SetFont(fontFamily, "B", 11)
+		pdf.CellFormat(50, 7, "หมายเหตุ", "", 0, "L", false, 0, "")
+		pdf.SetFont(fontFamily, "", 11)
+		pdf.MultiCell(0, 7, kid.Note, "", "L", false)
+	}
+}
+
+func writeGrowth(pdf *gofpdf.Fpdf, rows []GrowthRow, chart []byte) {
+	writeHeading(pdf, "การเจริญเติบโต")
+	if len(rows) == 0 {
+		writeEmpty(pdf, "ยังไม่มีข้อมูลการเจริญเติบโต")
+		return
+	}
+
+	widths := []float64{28, 32, 25, 25, 30, 25, 25}
+	writeTableHeader(pdf, widths, []string{"วันที่", "อายุ", "น้ำหนัก (กก.)", "ความยาว (ซม.)", "รอบศีรษะ (ซม.)", "เปอร์เซ็นไทล์ น.น.", "เปอร์เซ็นไทล์ ยาว"})
+	pdf.SetFont(fontFamily, "", 10)
+	for _, row := range rows {
+		values := []string{
+			row.Date.Format("2006-01-02"),
+			row.Age,
+			fmt.Sprintf("%.2f", row.Weight),
+			fmt.Sprintf("%.1f", row.Length),
+			formatOptional(row.HeadCircumference, "%.1f"),
+			formatOptional(row.WeightPercentile, "%.1f"),
+			formatOptional(row.LengthPercentile, "%.1f"),
+		}
+		for i, value := range values {
+			pdf.CellFormat(widths[i], 7, value, "1", 0, "C", false, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+
+	if len(chart) == 0 {
+		return
+	}
+
+	pageWidth, pageHeight := pdf.GetPageSize()
+	left, _, right, bottom := pdf.GetMargins()
+	width := pageWidth - left - right
+	height := width * 500 / 800
+	if pdf.GetY()+height+6 > pageHeight-bottom {
+		pdf.AddPage()
+	}
+
+	options := gofpdf.ImageOptions{ImageType: "PNG"}
+	pdf.RegisterImageOptionsReader("growth-chart", options, bytes.NewReader(chart))
+	pdf.Ln(4)
+	pdf.ImageOptions("growth-chart", left, pdf.GetY(), width, height, true, options, 0, "")
+}
+
+func writeEvaluations(pdf *gofpdf.Fpdf, evaluations []Evaluation) {
+	writeHeading(pdf, "ผลการประเมินพัฒนาการ")
+	if len(evaluations) == 0 {
+		writeEmpty(pdf, "ยังไม่มีผลการประเมิน")
+		return
+	}
+
+	for _, evaluation := range evaluations {
+		pdf.SetFont(fontFamily, "B", 12)
+		title := evaluation.Period
+		if evaluation.CompletedAt != nil {
+			title += " (" + evaluation.CompletedAt.Format("2006-01-02") + ")"
+		}
+		pdf.CellFormat(0, 8, title, "", 1, "L", false, 0, "")
+		pdf.SetFont(fontFamily, "", 11)
+		pdf.CellFormat(0, 7, "ผลรวม: "+evaluation.Result, "", 1, "L", false, 0, "")
+
+		if len(evaluation.Categories) == 0 {
+			pdf.Ln(2)
+			continue
+		}
+
+		widths := []float64{90, 50, 50}
+		writeTableHeader(pdf, widths, []string{"ด้านพัฒนาการ", "ผล", "วันที่ประเมิน"})
+		pdf.SetFont(fontFamily, "", 10)
+		for _, category := range evaluation.Categories {
+			doneAt := "-"
+			if category.DoneAt != nil {
+				doneAt = category.DoneAt.Format("2006-01-02")
+			}
+
+			pdf.CellFormat(widths[0], 7, category.Category, "1", 0, "L", false, 0, "")
+			pdf.CellFormat(widths[1], 7, category.Result, "1", 0, "C", false, 0, "")
+			pdf.CellFormat(widths[2], 7, doneAt, "1", 1, "C", false, 0, "")
+		}
+		pdf.Ln(3)
+	}
+}
+
+func writeAppointments(pdf *gofpdf.Fpdf, appointments []Appointment) {
+	writeHeading(pdf, "นัดหมายที่จะถึง")
+	if len(appointments) == 0 {
+		writeEmpty(pdf, "ไม่มีนัดหมาย")
+		return
+	}
+
+	widths := []float64{28, 18, 50, 45, 49}
+	writeTableHeader(pdf, widths, []string{"วันที่", "เวลา", "หัวข้อ", "สถานที่", "แพทย์"})
+	pdf.SetFont(fontFamily, "", 10)
+	for _, app := range appointments {
+		pdf.CellFormat(widths[0], 7, app.Date.Format("2006-01-02"), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(widths[1], 7, app.StartTime.Format("15:04"), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(widths[2], 7, app.Title, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[3], 7, app.Building, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[4], 7, app.Doctor, "1", 1, "L", false, 0, "")
+		if app.Requirement != "" {
+			pdf.SetFont(fontFamily, "", 9)
+			pdf.MultiCell(0, 6, "การเตรียมตัว: "+app.Requirement, "1", "L", false)
+			pdf.SetFont(fontFamily, "", 10)
+		}
+	}
+}
+
+func writeTableHeader(pdf *gofpdf.Fpdf, widths []float64, headers []string) {
+	pdf.SetFont(fontFamily, "B", 10)
+	pdf.SetFillColor(240, 240, 240)
+	for i, header := range headers {
+		pdf.CellFormat(widths[i], 8, header, "1", 0, "C", true, 0, "")
+	}
+	pdf.Ln(-1)
+}
+
+func writeEmpty(pdf *gofpdf.Fpdf, text string) {
+	pdf.SetFont(fontFamily, "", 11)
+	pdf.CellFormat(0, 7, text, "", 1, "L", false, 0, "")
+}
+
+func formatOptional(value *float64, format string) string {
+	if value == nil {
+		return "-"
+	}
+
+	return fmt.Sprintf(format, *value)
+}
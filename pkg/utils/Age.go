@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"Beside-Mom-BE/pkg/growth"
 	"fmt"
 	"time"
 )
@@ -32,8 +33,7 @@ func CalculateAgeDetailed(birthDate time.Time) (int, int, int, error) {
 
 func CalculateAgeAdjusted(birthDate time.Time, beforeBirth int) (int, int, int, error) {
 	now := time.Now()
-	adjustmentDays := (40 * 7) - (beforeBirth * 7)
-	adjustedDate := birthDate.AddDate(0, 0, adjustmentDays)
+	adjustedDate := birthDate.AddDate(0, 0, growth.CorrectionDays(beforeBirth))
 	if adjustedDate.After(now) {
 		return 0, 0, 0, nil
 	}
@@ -57,6 +57,20 @@ func CalculateAgeAdjusted(birthDate time.Time, beforeBirth int) (int, int, int,
 	return years, months, days, nil
 }
 
+func CompareCorrectedAgeKid(birthDate time.Time, beforeBirth int, date time.Time) (int, error) {
+	correction := growth.CorrectionDays(beforeBirth)
+	if correction == 0 {
+		return CompareAgeKid(birthDate, date)
+	}
+
+	adjustedDate := birthDate.AddDate(0, 0, correction)
+	if date.Before(adjustedDate) {
+		return 0, nil
+	}
+
+	return CompareAgeKid(adjustedDate, date)
+}
+
 func CompareAgeKid(birthDate time.Time, date time.Time) (int, error) {
 	months := (date.Year() - birthDate.Year()) * 12
 	months += int(date.Month() - birthDate.Month())
@@ -66,3 +80,15 @@ func CompareAgeKid(birthDate time.Time, date time.Time) (int, error) {
 
 	return months, nil
 }
+
+func ScreeningDueWindow(birthDate time.Time, beforeBirth int, startMonths int, endMonths int) (time.Time, time.Time) {
+	base := birthDate.AddDate(0, 0, growth.CorrectionDays(beforeBirth))
+
+	dueFrom := base.AddDate(0, startMonths, 0)
+	dueTo := base.AddDate(0, endMonths, 0).AddDate(0, 0, -1)
+	if dueTo.Before(dueFrom) {
+		dueTo = dueFrom
+	}
+
+	return dueFrom, dueTo
+}
@@ -8,6 +8,7 @@ import (
 	"html/template"
 	"strconv"
 	"strings"
+	"time"
 
 	"gopkg.in/gomail.v2"
 )
@@ -86,6 +87,45 @@ func SendPasswordMail(templatePath string, user entities.User, password string,
 	return nil
 }
 
+func SendInviteMail(templatePath string, user entities.User, link string, expiresAt time.Time, config configs.Mail) error {
+	var body bytes.Buffer
+	t, err := template.ParseFiles(templatePath)
+	if err != nil {
+		return err
+	}
+
+	err = t.Execute(&body, struct {
+		Username  string
+		Link      string
+		ExpiresAt string
+	}{
+		Username:  user.Firstname,
+		Link:      link,
+		ExpiresAt: expiresAt.Format("02 Jan 2006 15:04"),
+	})
+
+	if err != nil {
+		return err
+	}
+
+	m := gomail.NewMessage()
+	m.SetHeader("From", config.Sender)
+	m.SetHeader("To", user.Email)
+	m.SetHeader("Subject", "You are invited to Beside Mom")
+	m.SetBody("text/html", body.String())
+	port, err := strconv.Atoi(config.Port)
+	if err != nil {
+		return err
+	}
+
+	d := gomail.NewDialer(config.Host, port, config.Sender, config.Key)
+	if err := d.DialAndSend(m); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func NormalizeEmail(email string) (string, error) {
 	email = strings.ToLower(email)
 	parts := strings.Split(email, "@")
@@ -0,0 +1,68 @@
+package utils
+
+import "Beside-Mom-BE/modules/entities"
+
+var evaluateResultLabels = map[string]map[string]string{
+	"th": {
+		entities.ResultPending:    "รอประเมิน",
+		entities.ResultInProgress: "กำลังประเมิน",
+		entities.ResultPassed:     "ผ่านการประเมิน",
+		entities.ResultFailed:     "ไม่ผ่านการประเมินบางประการ",
+	},
+	"en": {
+		entities.ResultPending:    "Not yet evaluated",
+		entities.ResultInProgress: "Evaluation in progress",
+		entities.ResultPassed:     "Passed",
+		entities.ResultFailed:     "Did not pass some items",
+	},
+}
+
+var categoryResultLabels = map[string]map[string]string{
+	"th": {
+		entities.ResultPending: "รอประเมิน",
+		entities.ResultPassed:  "ผ่าน",
+		entities.ResultFailed:  "ไม่ผ่าน",
+	},
+	"en": {
+		entities.ResultPending: "Not yet evaluated",
+		entities.ResultPassed:  "Passed",
+		entities.ResultFailed:  "Failed",
+	},
+}
+
+func EvaluateResultLabel(code string, lang string) string {
+	return resultLabel(evaluateResultLabels, code, lang)
+}
+
+func CategoryResultLabel(code string, lang string) string {
+	return resultLabel(categoryResultLabels, code, lang)
+}
+
+func CategoryPassed(passed int, total int, minPassed int) bool {
+	required := total
+	if minPassed > 0 && minPassed < total {
+		required = minPassed
+	}
+
+	return passed >= required
+}
+
+func AppliedMinPassed(applied *int, current int) int {
+	if applied != nil {
+		return *applied
+	}
+
+	return current
+}
+
+func resultLabel(labels map[string]map[string]string, code string, lang string) string {
+	if label, ok := labels[lang][code]; ok {
+		return label
+	}
+
+	if label, ok := labels["th"][code]; ok {
+		return label
+	}
+
+	return code
+}
@@ -71,6 +71,25 @@ func UploadVideo(fileName string, file io.Reader, config configs.Supabase) (stri
 	return url, nil
 }
 
+func UploadFile(fileName string, file io.Reader, contentType string, config configs.Supabase) (string, error) {
+	if config.URL == "" || config.Key == "" || config.Bucket == "" {
+		return "", fmt.Errorf("invalid Supabase config")
+	}
+
+	storageClient := storage_go.NewClient(config.URL, config.Key, nil)
+	options := storage_go.FileOptions{
+		ContentType: stringPtr(contentType),
+	}
+
+	_, err := storageClient.UploadFile(config.Bucket, fileName, file, options)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload file '%s' to bucket '%s': %w", fileName, config.Bucket, err)
+	}
+
+	url := fmt.Sprintf("%s/object/public/%s/%s", config.URL, config.Bucket, fileName)
+	return url, nil
+}
+
 func DeleteImage(fileURL string, config configs.Supabase) error {
 	if config.URL == "" || config.Key == "" || config.Bucket == "" {
 		return fmt.Errorf("invalid Supabase config")
@@ -87,6 +106,25 @@ func DeleteImage(fileURL string, config configs.Supabase) error {
 	return nil
 }
 
+func DownloadImage(fileURL string, config configs.Supabase) ([]byte, error) {
+	if config.URL == "" || config.Key == "" || config.Bucket == "" {
+		return nil, fmt.Errorf("invalid Supabase config")
+	}
+
+	fileName := extractFileNameFromURL(fileURL, config.Bucket)
+	if fileName == "" {
+		return nil, fmt.Errorf("file '%s' is not stored in bucket '%s'", fileURL, config.Bucket)
+	}
+
+	storageClient := storage_go.NewClient(config.URL, config.Key, nil)
+	data, err := storageClient.DownloadFile(config.Bucket, fileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download file: %w", err)
+	}
+
+	return data, nil
+}
+
 func extractFileNameFromURL(fullURL, bucketName string) string {
 	prefix := "/storage/v1/object/public/" + bucketName + "/"
 	index := strings.Index(fullURL, prefix)